@@ -0,0 +1,162 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+)
+
+// AppOptions are options used in conjunction with the [App] type
+type AppOptions struct {
+	// Shutdown are the options of the app's [GracefulShutdown]
+	Shutdown GracefulShutdownOptions
+	// Bootstrap are the options of the app's [Bootstrapper]
+	Bootstrap BootstrapOptions
+}
+
+// App composes a [Bootstrapper], a [ReadyCheck] and a [GracefulShutdown], so a component's initialization, readiness and
+// shutdown can be registered in one place. The ReadyCheck is attached to the GracefulShutdown, see [GracefulShutdown.AttachReadyCheck].
+type App struct {
+	bootstrapper *Bootstrapper
+	readyCheck   *ReadyCheck
+	gs           *GracefulShutdown
+}
+
+// AppComponent describes how a component takes part in the lifecycle of an [App]. All fields are optional.
+type AppComponent struct {
+	// Start initializes the component when the app starts
+	Start StepFn
+	// StartOptions configure how the component is initialized
+	StartOptions []StepOption
+	// Check reports the readiness of the component
+	Check ComponentCheck
+	// Stop shuts the component down when the app shuts down, or rolls back its initialization if the app fails to start. It is
+	// invoked at most once.
+	Stop func(ctx context.Context) error
+	// StopOptions configure how the component takes part in the shutdown
+	StopOptions []ComponentOption
+}
+
+// NewAppWithOptions creates a new instance of [*App] with the given options. The context is the parent of the AppContext.
+func NewAppWithOptions(ctx context.Context, options AppOptions) *App {
+	gs := NewGracefulShutdownWithOptions(ctx, options.Shutdown)
+	gs.state = newStateMachine(StateStarting)
+
+	rdy := NewReadyCheck()
+	gs.AttachReadyCheck(rdy)
+	gs.OnShutdownStart(rdy.StopPolling)
+
+	return &App{
+		bootstrapper: NewBootstrapperWithOptions(options.Bootstrap),
+		readyCheck:   rdy,
+		gs:           gs,
+	}
+}
+
+// NewApp creates a new instance of [*App]. Default options will be used.
+func NewApp(ctx context.Context) *App {
+	return NewAppWithOptions(ctx, AppOptions{})
+}
+
+// Bootstrapper returns the app's [Bootstrapper]
+func (app *App) Bootstrapper() *Bootstrapper {
+	return app.bootstrapper
+}
+
+// ReadyCheck returns the app's [ReadyCheck]
+func (app *App) ReadyCheck() *ReadyCheck {
+	return app.readyCheck
+}
+
+// GracefulShutdown returns the app's [GracefulShutdown]
+func (app *App) GracefulShutdown() *GracefulShutdown {
+	return app.gs
+}
+
+// AppContext is the app's context. It is done once the shutdown is requested.
+func (app *App) AppContext() context.Context {
+	return app.gs.AppContext()
+}
+
+// State returns the current state of the app. See [GracefulShutdown.State].
+func (app *App) State() State {
+	return app.gs.State()
+}
+
+// SubscribeState returns a channel which receives the current state of the app, then every subsequent transition.
+// See [GracefulShutdown.SubscribeState].
+func (app *App) SubscribeState() <-chan State {
+	return app.gs.SubscribeState()
+}
+
+// Register registers a component's initialization step, readiness check and shutdown function, depending on which ones are set.
+// If the component cannot be registered, none of them is.
+func (app *App) Register(name string, component AppComponent) error {
+	var stop func(ctx context.Context) error
+	if component.Stop != nil {
+		once := &sync.Once{}
+		stop = func(ctx context.Context) error {
+			var err error
+			once.Do(func() {
+				err = component.Stop(ctx)
+			})
+
+			return err
+		}
+	}
+
+	if component.Start != nil {
+		opts := component.StartOptions
+		if stop != nil {
+			opts = append(opts, WithRollback(stop))
+		}
+
+		err := app.bootstrapper.AddStep(name, component.Start, opts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if stop != nil {
+		err := app.gs.registerComponentFn(name, DefaultPhase, stop, component.StopOptions)
+		if err != nil {
+			if component.Start != nil {
+				app.bootstrapper.removeStep(name)
+			}
+
+			return err
+		}
+	}
+
+	if component.Check != nil {
+		app.readyCheck.RegisterComponent(name, component.Check)
+	}
+
+	return nil
+}
+
+// Start performs the initialization steps, then starts polling the readiness checks and moves the app to the [StateRunning]
+// state. If the initialization fails, the components which were already initialized are stopped, the app moves to the
+// [StateFailed] state and a [StartupError] is returned.
+func (app *App) Start(ctx context.Context) error {
+	err := app.bootstrapper.Start(ctx)
+	if err != nil {
+		app.gs.state.transition(StateFailed)
+		return err
+	}
+
+	app.readyCheck.StartPolling()
+	app.gs.state.transition(StateRunning)
+
+	return nil
+}
+
+// Shutdown stops polling the readiness checks and shuts the components down. See [GracefulShutdown.Shutdown].
+func (app *App) Shutdown() error {
+	return app.gs.Shutdown()
+}
+
+// WaitForShutdown blocks until the shutdown is triggered, then stops polling the readiness checks and shuts the components down.
+// See [GracefulShutdown.WaitForShutdown].
+func (app *App) WaitForShutdown() error {
+	return app.gs.WaitForShutdown()
+}
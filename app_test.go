@@ -0,0 +1,94 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenAppComponentIsRegistered_ShouldWireStartReadinessAndStop(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	started := atomic.Bool{}
+	stopped := atomic.Int32{}
+	check := lifecycle.NewReadyCheck().RegisterPushComponent("db")
+
+	err := app.Register("db", lifecycle.AppComponent{
+		Start: func(ctx context.Context) error {
+			started.Store(true)
+			check.SetReady(true)
+			return nil
+		},
+		Check: check,
+		Stop: func(ctx context.Context) error {
+			stopped.Add(1)
+			return nil
+		},
+	})
+	assert.NoError(err)
+
+	assert.False(app.ReadyCheck().Ready(), "component should not be ready before startup")
+
+	assert.NoError(app.Start(context.Background()))
+	assert.True(started.Load())
+	assert.True(app.ReadyCheck().Ready())
+
+	assert.NoError(app.Shutdown())
+	assert.Equal(int32(1), stopped.Load())
+}
+
+func Test_WhenAppFailsToStart_ShouldStopStartedComponentsOnce(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	stopped := atomic.Int32{}
+	expectedErr := errors.New("connection refused")
+
+	_ = app.Register("db", lifecycle.AppComponent{
+		Start: func(ctx context.Context) error {
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			stopped.Add(1)
+			return nil
+		},
+	})
+	_ = app.Register("cache", lifecycle.AppComponent{
+		Start: func(ctx context.Context) error {
+			return expectedErr
+		},
+	})
+
+	err := app.Start(context.Background())
+	assert.ErrorIs(err, expectedErr)
+	assert.Equal(int32(1), stopped.Load(), "started component should be rolled back")
+
+	assert.NoError(app.Shutdown())
+	assert.Equal(int32(1), stopped.Load(), "rolled back component should not be stopped twice")
+}
+
+func Test_WhenAppComponentCannotBeRegistered_ShouldNotAddItsStep(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	assert.NoError(app.GracefulShutdown().RegisterComponentWithFn("db", func() error { return nil }))
+
+	err := app.Register("db", lifecycle.AppComponent{
+		Start: func(ctx context.Context) error {
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			return nil
+		},
+	})
+	assert.ErrorIs(err, lifecycle.ErrComponentAlreadyRegistered)
+
+	steps, err := app.Bootstrapper().Steps()
+	assert.NoError(err)
+	assert.Empty(steps)
+}
@@ -0,0 +1,84 @@
+package lifecycle
+
+// CheckOption configures how a component takes part in the readiness of the [ReadyCheck]
+type CheckOption func(s *checkSettings)
+
+type checkSettings struct {
+	nonCritical     bool
+	tags            []string
+	excludeLiveness bool
+	componentType   string
+}
+
+// NonCritical marks the component as non-critical. A non-critical component is reported by [ReadyCheck.Explain], but never
+// makes the [ReadyCheck] not ready: when it is not ready, the [ReadyCheck] is only reported as [StatusDegraded].
+func NonCritical() CheckOption {
+	return func(s *checkSettings) {
+		s.nonCritical = true
+	}
+}
+
+// WithTags tags the component, so that it can be selected by [ReadyCheck.ReadyForTags], [ReadyCheck.ExplainForTags] and
+// [ReadyCheck.HandlerForTags]. It allows serving several probes, each checking a different subset of the components.
+func WithTags(tags ...string) CheckOption {
+	return func(s *checkSettings) {
+		s.tags = append(s.tags, tags...)
+	}
+}
+
+// ExcludeFromLiveness excludes a poll or pulse check from the liveness of the [ReadyCheck]. See [ReadyCheck.Live]. It is meant
+// for checks of external dependencies, such as a database, whose failure would not be fixed by restarting the process.
+func ExcludeFromLiveness() CheckOption {
+	return func(s *checkSettings) {
+		s.excludeLiveness = true
+	}
+}
+
+// WithComponentType sets the type of the component reported by [ReadyCheck.HealthDocument], such as
+// [HealthComponentTypeDatastore]. It overrides the type derived from the kind of check.
+func WithComponentType(componentType string) CheckOption {
+	return func(s *checkSettings) {
+		s.componentType = componentType
+	}
+}
+
+// selectComponents returns a snapshot of the components tagged with at least one of the given tags, or of all the components
+// when no tag is given
+func (rdy *ReadyCheck) selectComponents(tags []string) []ComponentCheck {
+	components := rdy.snapshotComponents()
+	if len(tags) == 0 {
+		return components
+	}
+
+	rdy.componentsMutex.RLock()
+	defer rdy.componentsMutex.RUnlock()
+
+	selected := make([]ComponentCheck, 0, len(components))
+	for _, component := range components {
+		if hasAnyTag(rdy.settings[component.Name()].tags, tags) {
+			selected = append(selected, component)
+		}
+	}
+
+	return selected
+}
+
+func hasAnyTag(componentTags []string, tags []string) bool {
+	for _, componentTag := range componentTags {
+		for _, tag := range tags {
+			if componentTag == tag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isCritical returns true if the component affects the overall readiness
+func (rdy *ReadyCheck) isCritical(component ComponentCheck) bool {
+	rdy.componentsMutex.RLock()
+	defer rdy.componentsMutex.RUnlock()
+
+	return !rdy.settings[component.Name()].nonCritical
+}
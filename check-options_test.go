@@ -0,0 +1,82 @@
+package lifecycle_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenNonCriticalComponentIsNotReady_ShouldStayReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readycheck.OnReadyChange(recorder.onReadyChange)
+
+	readycheck.RegisterPushComponent("db").SetReady(true)
+	metrics := readycheck.RegisterPushComponent("metrics", lifecycle.NonCritical())
+	metrics.SetReady(true)
+	metrics.SetReady(false)
+
+	assert.True(readycheck.Ready())
+	assert.Equal(map[string]bool{"db": true, "metrics": false}, readycheck.Explain())
+	assert.Equal(lifecycle.StatusDegraded, readycheck.Status())
+
+	_, overall := recorder.snapshot()
+	assert.Equal([]bool{true}, overall, "overall readiness should only have changed once db was ready")
+
+	status, report := serveReadiness(readycheck)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal([]string{"metrics"}, report.Warnings)
+}
+
+func Test_WhenNonCriticalComponentIsUnregistered_ShouldForgetIt(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("metrics", lifecycle.NonCritical())
+	readycheck.UnregisterComponent("metrics")
+
+	readycheck.RegisterPushComponent("metrics")
+
+	assert.False(readycheck.Ready(), "component registered again should be critical")
+}
+
+func Test_WhenComponentsAreTagged_ShouldFilterByTags(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("db", lifecycle.WithTags("traffic", "storage")).SetReady(true)
+	readycheck.RegisterPushComponent("cache", lifecycle.WithTags("traffic")).SetReady(true)
+	readycheck.RegisterPushComponent("backup", lifecycle.WithTags("storage"))
+
+	assert.False(readycheck.Ready())
+	assert.True(readycheck.ReadyForTags("traffic"))
+	assert.False(readycheck.ReadyForTags("storage"))
+	assert.Equal(map[string]bool{"db": true, "backup": false}, readycheck.ExplainForTags("storage"))
+	assert.Equal(map[string]bool{"db": true, "cache": true, "backup": false}, readycheck.ExplainForTags("traffic", "storage"))
+
+	readycheck.SetDraining(true)
+	assert.False(readycheck.ReadyForTags("traffic"), "draining should apply to tagged components")
+}
+
+func Test_WhenServingTaggedComponents_ShouldOnlyReportThem(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("db", lifecycle.WithTags("traffic")).SetReady(true)
+	readycheck.RegisterPushComponent("backup", lifecycle.WithTags("storage"))
+
+	recorder := httptest.NewRecorder()
+	readycheck.HandlerForTags("traffic").ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	report := lifecycle.ReadinessReport{}
+	assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(http.StatusOK, recorder.Code)
+	assert.True(report.Ready)
+	assert.Equal(map[string]bool{"db": true}, report.Components)
+}
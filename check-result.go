@@ -0,0 +1,97 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// CheckResult is the detailed outcome of a component check
+type CheckResult struct {
+	// Ready is true if the component is ready
+	Ready bool
+	// Err explains why the component is not ready, if known
+	Err error
+	// Details are additional information about the component's state
+	Details map[string]string
+	// CheckedAt is the time at which the check was performed. It is zero if the check was never performed.
+	CheckedAt time.Time
+}
+
+// MarshalJSON encodes the result as a JSON object, with the error as its message. The check time is omitted if the check was
+// never performed.
+func (result CheckResult) MarshalJSON() ([]byte, error) {
+	document := struct {
+		Ready     bool              `json:"ready"`
+		Error     string            `json:"error,omitempty"`
+		Details   map[string]string `json:"details,omitempty"`
+		CheckedAt *time.Time        `json:"checkedAt,omitempty"`
+	}{
+		Ready:   result.Ready,
+		Details: result.Details,
+	}
+
+	if result.Err != nil {
+		document.Error = result.Err.Error()
+	}
+
+	if !result.CheckedAt.IsZero() {
+		document.CheckedAt = &result.CheckedAt
+	}
+
+	return json.Marshal(document)
+}
+
+// DetailedCheck is a [ComponentCheck] able to report more than its readiness
+type DetailedCheck interface {
+	ComponentCheck
+	// Result returns the outcome of the last check
+	Result() CheckResult
+}
+
+var (
+	ErrPulseNotRecorded = errors.New("no pulse was recorded")
+	ErrPulseExpired     = errors.New("last pulse has expired")
+	ErrPulseRateTooLow  = errors.New("not enough pulses were recorded within the window")
+	ErrPulseLoopExited  = errors.New("pulsing loop has exited")
+)
+
+// ExplainDetailed returns a map detailling the outcome of each component's check. Components which do not implement
+// [DetailedCheck] only report their readiness, checked at the time of the call.
+func (rdy *ReadyCheck) ExplainDetailed() map[string]CheckResult {
+	return rdy.explainDetailed(rdy.snapshotComponents())
+}
+
+func (rdy *ReadyCheck) explainDetailed(components []ComponentCheck) map[string]CheckResult {
+	results := checkComponents(components, rdy.options.CheckTimeout, checkResult, func(ComponentCheck) CheckResult {
+		return CheckResult{Err: ErrCheckTimeout, CheckedAt: time.Now()}
+	})
+
+	explanation := make(map[string]CheckResult, len(components))
+
+	for i, component := range components {
+		explanation[component.Name()] = results[i]
+	}
+
+	return explanation
+}
+
+func checkResult(component ComponentCheck) CheckResult {
+	if detailed, ok := component.(DetailedCheck); ok {
+		return detailed.Result()
+	}
+
+	return CheckResult{
+		Ready:     component.Ready(),
+		CheckedAt: time.Now(),
+	}
+}
+
+func loadTime(t *atomic.Pointer[time.Time]) time.Time {
+	if value := t.Load(); value != nil {
+		return *value
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,100 @@
+package lifecycle_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakeComponentCheck struct {
+	name    string
+	isReady bool
+}
+
+func (c fakeComponentCheck) Name() string {
+	return c.name
+}
+
+func (c fakeComponentCheck) Ready() bool {
+	return c.isReady
+}
+
+func Test_WhenExplainingInDetail_ShouldReportCheckResults(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+
+	beforePush := time.Now()
+	readycheck.RegisterPushComponent("push").SetReady(true)
+	readycheck.RegisterPulseComponent("pulse", 50*time.Millisecond)
+	expiredPulse := readycheck.RegisterPulseComponent("expired-pulse", 10*time.Millisecond)
+	expiredPulse.RecordPulse()
+	readycheck.RegisterComponent("custom", fakeComponentCheck{name: "custom", isReady: true})
+
+	time.Sleep(20 * time.Millisecond)
+
+	explanation := readycheck.ExplainDetailed()
+	assert.Len(explanation, 4)
+
+	assert.True(explanation["push"].Ready)
+	assert.False(explanation["push"].CheckedAt.Before(beforePush), "push should be checked when it was set")
+
+	assert.False(explanation["pulse"].Ready)
+	assert.ErrorIs(explanation["pulse"].Err, lifecycle.ErrPulseNotRecorded)
+
+	assert.False(explanation["expired-pulse"].Ready)
+	assert.ErrorIs(explanation["expired-pulse"].Err, lifecycle.ErrPulseExpired)
+	assert.Contains(explanation["expired-pulse"].Details, "lastPulse")
+	assert.Contains(explanation["expired-pulse"].Details, "sinceLastPulse")
+	assert.Equal("1", explanation["expired-pulse"].Details["pulseCount"])
+
+	assert.True(explanation["custom"].Ready)
+	assert.False(explanation["custom"].CheckedAt.IsZero())
+}
+
+func Test_WhenComponentWasNeverPolled_ShouldReportZeroCheckTime(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	poll := readycheck.RegisterPollComponent("poll", func() bool {
+		return true
+	}, 10*time.Millisecond)
+
+	assert.True(poll.Result().CheckedAt.IsZero())
+
+	readycheck.StartPolling()
+	defer readycheck.StopPolling()
+
+	assert.Eventually(func() bool {
+		return poll.Result().Ready && !poll.Result().CheckedAt.IsZero()
+	}, time.Second, 5*time.Millisecond)
+}
+
+func Test_WhenPushCheckIsSetWithReason_ShouldExplainIt(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	migrations := readycheck.RegisterPushComponent("migrations")
+	broker := readycheck.RegisterPushComponent("broker")
+
+	migrations.SetReadyWithReason(false, "migrating schema")
+	errDisconnected := errors.New("disconnected")
+	broker.SetError(errDisconnected)
+
+	explanation := readycheck.ExplainDetailed()
+	assert.False(explanation["migrations"].Ready)
+	assert.Equal("migrating schema", explanation["migrations"].Details["reason"])
+	assert.False(explanation["broker"].Ready)
+	assert.ErrorIs(explanation["broker"].Err, errDisconnected)
+
+	broker.SetError(nil)
+	migrations.SetReady(true)
+
+	explanation = readycheck.ExplainDetailed()
+	assert.True(explanation["broker"].Ready)
+	assert.NoError(explanation["broker"].Err)
+	assert.Empty(explanation["migrations"].Details)
+}
@@ -0,0 +1,88 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time to a [GracefulShutdown]. It measures the duration of the shutdown and bounds its delays and timeouts.
+// Tests may provide their own implementation to control the passing of time, see the lifecycletest package.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After returns a channel receiving the current time once the duration has elapsed
+	After(d time.Duration) <-chan time.Time
+	// NewTicker returns a [Ticker] delivering the current time every period
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers the time at intervals, like a [time.Ticker]
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered
+	C() <-chan time.Time
+	// Reset stops the ticker, discards a tick which was not received yet, and restarts it with the given period
+	Reset(d time.Duration)
+	// Stop turns off the ticker
+	Stop()
+}
+
+// SystemClock is the [Clock] telling the time of the system
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{ticker: time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (ticker systemTicker) C() <-chan time.Time {
+	return ticker.ticker.C
+}
+
+func (ticker systemTicker) Reset(d time.Duration) {
+	ticker.ticker.Reset(d)
+
+	select {
+	case <-ticker.ticker.C:
+	default:
+	}
+}
+
+func (ticker systemTicker) Stop() {
+	ticker.ticker.Stop()
+}
+
+// withTimeout returns a copy of the context which is done once the timeout elapses on the clock. With the system clock, the
+// context carries a deadline. With another clock, the context is cancelled with [context.DeadlineExceeded] as its cause.
+func withTimeout(ctx context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if clock == SystemClock {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	elapsed := clock.After(timeout)
+
+	go func() {
+		select {
+		case <-elapsed:
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		cancel(context.Canceled)
+	}
+}
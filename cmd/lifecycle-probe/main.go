@@ -0,0 +1,29 @@
+// Command lifecycle-probe checks a health endpoint and exits with 0 if it is healthy, 1 otherwise. It is meant to be used in
+// container health checks:
+//
+//	HEALTHCHECK CMD ["/lifecycle-probe", "http://localhost:8080/ready"]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gretro/go-lifecycle"
+)
+
+func main() {
+	flag.DurationVar(&lifecycle.DefaultProbeTimeout, "timeout", lifecycle.DefaultProbeTimeout, "time allocated to the probe")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-timeout 5s] <url>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	os.Exit(lifecycle.ProbeHTTP(flag.Arg(0)))
+}
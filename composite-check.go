@@ -0,0 +1,105 @@
+package lifecycle
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// CompositeComponentCheck is a component check which is ready when enough of its checks are ready. It allows expressing
+// redundancy-aware readiness, such as "ready if 2 of 3 brokers are reachable". Its checks are evaluated concurrently, each
+// within the [ReadyCheckOptions.CheckTimeout] of the ReadyCheck it is registered in, or the [DefaultCheckTimeout] until then.
+type CompositeComponentCheck struct {
+	name     string
+	checks   []ComponentCheck
+	required int
+	timeout  *atomic.Int64
+}
+
+// All creates a [CompositeComponentCheck] which is ready when all the given checks are ready
+func All(name string, checks ...ComponentCheck) ComponentCheck {
+	return AtLeast(name, len(checks), checks...)
+}
+
+// Any creates a [CompositeComponentCheck] which is ready when at least one of the given checks is ready
+func Any(name string, checks ...ComponentCheck) ComponentCheck {
+	return AtLeast(name, 1, checks...)
+}
+
+// AtLeast creates a [CompositeComponentCheck] which is ready when at least n of the given checks are ready
+func AtLeast(name string, n int, checks ...ComponentCheck) ComponentCheck {
+	return &CompositeComponentCheck{
+		name:     name,
+		checks:   checks,
+		required: n,
+		timeout:  &atomic.Int64{},
+	}
+}
+
+// Name is the name of the component being checked for
+func (component *CompositeComponentCheck) Name() string {
+	return component.name
+}
+
+// Ready returns true if enough checks are ready
+func (component *CompositeComponentCheck) Ready() bool {
+	readyCount := 0
+	for _, isReady := range component.checkReadiness() {
+		if isReady {
+			readyCount++
+		}
+	}
+
+	return readyCount >= component.required
+}
+
+// Result returns the readiness of the component, and the readiness of each of its checks
+func (component *CompositeComponentCheck) Result() CheckResult {
+	readiness := component.checkReadiness()
+
+	result := CheckResult{
+		Details:   make(map[string]string, len(component.checks)),
+		CheckedAt: time.Now(),
+	}
+
+	readyCount := 0
+	for i, check := range component.checks {
+		if readiness[i] {
+			readyCount++
+		}
+		result.Details[check.Name()] = strconv.FormatBool(readiness[i])
+	}
+
+	result.Ready = readyCount >= component.required
+	if !result.Ready {
+		result.Err = fmt.Errorf("%d of %d checks are ready, %d required", readyCount, len(component.checks), component.required)
+	}
+
+	return result
+}
+
+// setCheckTimeout sets the time allocated to each check, including the checks of nested composite checks
+func (component *CompositeComponentCheck) setCheckTimeout(timeout time.Duration) {
+	component.timeout.Store(int64(timeout))
+
+	for _, check := range component.checks {
+		if composite, ok := check.(*CompositeComponentCheck); ok {
+			composite.setCheckTimeout(timeout)
+		}
+	}
+}
+
+func (component *CompositeComponentCheck) checkTimeout() time.Duration {
+	if timeout := time.Duration(component.timeout.Load()); timeout > 0 {
+		return timeout
+	}
+
+	return DefaultCheckTimeout
+}
+
+func (component *CompositeComponentCheck) checkReadiness() []bool {
+	return checkComponents(component.checks, component.checkTimeout(), ComponentCheck.Ready, func(ComponentCheck) bool {
+		return false
+	})
+}
@@ -0,0 +1,58 @@
+package lifecycle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenCompositeChecksAreEvaluated_ShouldRequireEnoughReadyChecks(t *testing.T) {
+	assert := assert2.New(t)
+
+	brokers := []lifecycle.ComponentCheck{
+		fakeComponentCheck{name: "broker-1", isReady: true},
+		fakeComponentCheck{name: "broker-2", isReady: false},
+		fakeComponentCheck{name: "broker-3", isReady: true},
+	}
+
+	assert.False(lifecycle.All("brokers", brokers...).Ready())
+	assert.True(lifecycle.Any("brokers", brokers...).Ready())
+	assert.True(lifecycle.AtLeast("brokers", 2, brokers...).Ready())
+	assert.False(lifecycle.AtLeast("brokers", 3, brokers...).Ready())
+	assert.False(lifecycle.Any("brokers").Ready(), "no check can be ready")
+	assert.True(lifecycle.All("brokers").Ready(), "no check is required")
+}
+
+func Test_WhenCompositeCheckIsNotReady_ShouldExplainIt(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("brokers", lifecycle.AtLeast("brokers", 2,
+		fakeComponentCheck{name: "broker-1", isReady: true},
+		fakeComponentCheck{name: "broker-2", isReady: false},
+	))
+
+	assert.False(readycheck.Ready())
+
+	result := readycheck.ExplainDetailed()["brokers"]
+	assert.EqualError(result.Err, "1 of 2 checks are ready, 2 required")
+	assert.Equal(map[string]string{"broker-1": "true", "broker-2": "false"}, result.Details)
+}
+
+func Test_WhenCompositeCheckIsRegistered_ShouldUseCheckTimeout(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		CheckTimeout: 50 * time.Millisecond,
+	})
+	brokers := lifecycle.Any("brokers", lifecycle.All("zone-a",
+		slowComponentCheck{name: "broker-1", delay: 300 * time.Millisecond},
+	))
+	readycheck.RegisterComponent("brokers", brokers)
+
+	start := time.Now()
+	assert.False(brokers.Ready(), "nested check should time out")
+	assert.Less(time.Since(start), 250*time.Millisecond, "nested checks should be bounded by the check timeout")
+}
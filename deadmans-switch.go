@@ -0,0 +1,66 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrDeadMansSwitchTripped = errors.New("dead man's switch tripped")
+
+// DeadMansSwitchOptions are options used in conjunction with [GracefulShutdown.AttachDeadMansSwitch]
+type DeadMansSwitchOptions struct {
+	// Threshold is the time without any pulse after which the shutdown is triggered. It is usually greater than the expiration of
+	// the pulse, so the component is first reported as not ready, and the application is only restarted if it does not recover.
+	//
+	// Default: the Expiration of the pulse
+	Threshold time.Duration
+	// ExitAfterShutdown exits the process once the shutdown triggered by the switch completes, with the exit code matching its
+	// outcome. See [GracefulShutdown.ExitCode].
+	//
+	// Default: false
+	ExitAfterShutdown bool
+}
+
+// AttachDeadMansSwitch triggers the shutdown when the pulse goes stale for longer than the threshold, so that a deadlocked loop
+// results in a clean restart by the orchestrator instead of a process which stays up without doing any work. The shutdown reason
+// wraps [ErrDeadMansSwitchTripped].
+//
+// The switch is armed by the first pulse, so a loop which did not start yet does not trip it.
+func (gs *GracefulShutdown) AttachDeadMansSwitch(pulse *PulseComponentCheck, options DeadMansSwitchOptions) {
+	if options.Threshold <= 0 {
+		options.Threshold = pulse.options.Expiration
+	}
+
+	pulse.OnExpire(func(name string, lastPulse time.Time) {
+		delay := options.Threshold - time.Since(lastPulse)
+
+		time.AfterFunc(delay, func() {
+			latestPulse, _ := pulse.LastPulse()
+			if latestPulse.After(lastPulse) {
+				// The pulse recovered. The switch is armed again if it expires once more.
+				return
+			}
+
+			gs.tripDeadMansSwitch(name, options)
+		})
+	})
+}
+
+func (gs *GracefulShutdown) tripDeadMansSwitch(name string, options DeadMansSwitchOptions) {
+	reason := fmt.Errorf("%w: %s did not pulse for %s", ErrDeadMansSwitchTripped, name, options.Threshold)
+
+	if !options.ExitAfterShutdown {
+		gs.requestShutdown(reason)
+		return
+	}
+
+	err := gs.shutdown(context.Background(), reason)
+	if errors.Is(err, ErrAlreadyShutdown) {
+		// The shutdown was already triggered, and is handled by whoever triggered it
+		return
+	}
+
+	exit(gs.ExitCode(err))
+}
@@ -0,0 +1,94 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenPulseStaysStale_ShouldTripDeadMansSwitch(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	rdy := lifecycle.NewReadyCheck()
+
+	pulse := rdy.RegisterPulseComponent("main-loop", 20*time.Millisecond)
+	gs.AttachDeadMansSwitch(pulse, lifecycle.DeadMansSwitchOptions{
+		Threshold: 60 * time.Millisecond,
+	})
+
+	pulse.RecordPulse()
+
+	select {
+	case <-gs.AppContext().Done():
+	case <-time.After(time.Second):
+		assert.Fail("dead man's switch should have triggered the shutdown")
+		return
+	}
+
+	assert.True(errors.Is(gs.ShutdownReason(), lifecycle.ErrDeadMansSwitchTripped))
+
+	since, _ := pulse.SinceLastPulse()
+	assert.GreaterOrEqual(since, 60*time.Millisecond, "switch should not trip before the threshold")
+}
+
+func Test_WhenPulseRecovers_ShouldNotTripDeadMansSwitch(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	rdy := lifecycle.NewReadyCheck()
+
+	pulse := rdy.RegisterPulseComponent("main-loop", 20*time.Millisecond)
+	gs.AttachDeadMansSwitch(pulse, lifecycle.DeadMansSwitchOptions{
+		Threshold: 80 * time.Millisecond,
+	})
+
+	pulse.RecordPulse()
+	time.Sleep(40 * time.Millisecond)
+	pulse.RecordPulse()
+	time.Sleep(60 * time.Millisecond)
+	pulse.RecordPulse()
+
+	assert.NoError(gs.AppContext().Err(), "pulse recovered before the threshold")
+}
+
+func Test_WhenDeadMansSwitchTripsWithExit_ShouldExitAfterShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	rdy := lifecycle.NewReadyCheck()
+
+	exitCodes := make(chan int, 1)
+	restore := lifecycle.SetExitFunc(func(code int) {
+		exitCodes <- code
+	})
+	defer restore()
+
+	drained := make(chan struct{})
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", func() error {
+		close(drained)
+		return nil
+	}))
+
+	pulse := rdy.RegisterPulseComponent("main-loop", 20*time.Millisecond)
+	gs.AttachDeadMansSwitch(pulse, lifecycle.DeadMansSwitchOptions{
+		ExitAfterShutdown: true,
+	})
+
+	pulse.RecordPulse()
+
+	select {
+	case code := <-exitCodes:
+		assert.Equal(0, code)
+	case <-time.After(time.Second):
+		assert.Fail("process should have exited")
+		return
+	}
+
+	select {
+	case <-drained:
+	default:
+		assert.Fail("components should be drained before exiting")
+	}
+}
@@ -0,0 +1,22 @@
+package lifecycle
+
+// Defer registers a cleanup function as a component, for trivial resources which do not need any channel plumbing. Deferred
+// functions are invoked in the reverse order of their registration, like deferred function calls: each one waits for the
+// functions deferred after it. Like other components, they are part of the [DefaultPhase] and are bounded by the shutdown
+// timeout.
+func (gs *GracefulShutdown) Defer(name string, fn func() error) error {
+	return gs.RegisterComponentWithFn(name, fn, func(c *component) {
+		c.deferred = true
+	})
+}
+
+// lastDeferred returns the most recent deferred component which is still registered. The component mutex must be held.
+func (gs *GracefulShutdown) lastDeferred() *component {
+	for i := len(gs.deferred) - 1; i >= 0; i-- {
+		if previous, ok := gs.components[gs.deferred[i]]; ok {
+			return previous
+		}
+	}
+
+	return nil
+}
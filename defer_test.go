@@ -0,0 +1,45 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenFunctionsAreDeferred_ShouldRunThemInLIFOOrder(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	mutex := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string) func() error {
+		return func() error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, name)
+
+			return nil
+		}
+	}
+
+	assert.NoError(gs.Defer("temp-dir", record("temp-dir")))
+	assert.NoError(gs.Defer("log-file", record("log-file")))
+	assert.NoError(gs.Defer("scratch-file", record("scratch-file")))
+	assert.NoError(gs.UnregisterComponent("scratch-file"))
+	assert.NoError(gs.Defer("lock-file", record("lock-file")))
+
+	assert.NoError(gs.Shutdown())
+
+	assert.Equal([]string{"lock-file", "log-file", "temp-dir"}, order)
+}
+
+func Test_WhenDeferredNameIsTaken_ShouldReturnError(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.NoError(gs.Defer("temp-dir", func() error { return nil }))
+	assert.ErrorIs(gs.Defer("temp-dir", func() error { return nil }), lifecycle.ErrComponentAlreadyRegistered)
+}
@@ -0,0 +1,101 @@
+package lifecycle
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrLowDiskSpace          = errors.New("free disk space is below the threshold")
+	ErrDiskUsageNotSupported = errors.New("disk usage is not supported on this platform")
+)
+
+// DiskSpaceOptions are options used in conjunction with the [DiskSpaceComponentCheck] type. Thresholds which are zero are not
+// enforced.
+type DiskSpaceOptions struct {
+	// Path is a path on the file system whose free space is checked
+	Path string
+	// MinFreeBytes is the free space under which the component is not ready
+	MinFreeBytes uint64
+	// MinFreePercent is the percentage of free space, between 0 and 100, under which the component is not ready
+	MinFreePercent float64
+	// DegradedFreeBytes is the free space under which the component is degraded
+	DegradedFreeBytes uint64
+	// DegradedFreePercent is the percentage of free space, between 0 and 100, under which the component is degraded
+	DegradedFreePercent float64
+}
+
+// DiskSpaceComponentCheck is a component check reporting the free space on a file system
+type DiskSpaceComponentCheck struct {
+	name    string
+	options DiskSpaceOptions
+}
+
+// DiskSpaceCheck creates a [DiskSpaceComponentCheck] measuring the free space each time it is checked
+func DiskSpaceCheck(name string, options DiskSpaceOptions) ComponentCheck {
+	return &DiskSpaceComponentCheck{
+		name:    name,
+		options: options,
+	}
+}
+
+// Name is the name of the component being checked for
+func (component *DiskSpaceComponentCheck) Name() string {
+	return component.name
+}
+
+// Ready returns true if the free space is above the not ready thresholds
+func (component *DiskSpaceComponentCheck) Ready() bool {
+	return component.Status() != StatusNotReady
+}
+
+// Status returns [StatusNotReady] or [StatusDegraded] when the free space is below their thresholds
+func (component *DiskSpaceComponentCheck) Status() Status {
+	status, _ := component.check()
+
+	return status
+}
+
+// Result returns the readiness of the component, and the free and total space of the file system
+func (component *DiskSpaceComponentCheck) Result() CheckResult {
+	status, result := component.check()
+	result.Ready = status != StatusNotReady
+
+	return result
+}
+
+func (component *DiskSpaceComponentCheck) check() (Status, CheckResult) {
+	result := CheckResult{CheckedAt: time.Now()}
+
+	free, total, err := diskUsage(component.options.Path)
+	if err != nil {
+		result.Err = err
+		return StatusNotReady, result
+	}
+
+	freePercent := 0.0
+	if total > 0 {
+		freePercent = float64(free) / float64(total) * 100
+	}
+
+	result.Details = map[string]string{
+		"freeBytes":   strconv.FormatUint(free, 10),
+		"totalBytes":  strconv.FormatUint(total, 10),
+		"freePercent": strconv.FormatFloat(freePercent, 'f', 2, 64),
+	}
+
+	switch {
+	case isBelow(free, freePercent, component.options.MinFreeBytes, component.options.MinFreePercent):
+		result.Err = ErrLowDiskSpace
+		return StatusNotReady, result
+	case isBelow(free, freePercent, component.options.DegradedFreeBytes, component.options.DegradedFreePercent):
+		return StatusDegraded, result
+	default:
+		return StatusReady, result
+	}
+}
+
+func isBelow(free uint64, freePercent float64, minBytes uint64, minPercent float64) bool {
+	return (minBytes > 0 && free < minBytes) || (minPercent > 0 && freePercent < minPercent)
+}
@@ -0,0 +1,56 @@
+//go:build linux || darwin || freebsd || windows
+
+package lifecycle_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenDiskSpaceIsAboveThresholds_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("disk", lifecycle.DiskSpaceCheck("disk", lifecycle.DiskSpaceOptions{
+		Path:         t.TempDir(),
+		MinFreeBytes: 1,
+	}))
+
+	assert.Equal(lifecycle.StatusReady, readycheck.Status())
+
+	result := readycheck.ExplainDetailed()["disk"]
+	assert.True(result.Ready)
+	assert.Contains(result.Details, "freeBytes")
+	assert.Contains(result.Details, "totalBytes")
+	assert.Contains(result.Details, "freePercent")
+}
+
+func Test_WhenDiskSpaceIsBelowThresholds_ShouldReportStatus(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("degraded", lifecycle.DiskSpaceCheck("degraded", lifecycle.DiskSpaceOptions{
+		Path:                t.TempDir(),
+		DegradedFreePercent: 100.1,
+	}))
+	readycheck.RegisterComponent("full", lifecycle.DiskSpaceCheck("full", lifecycle.DiskSpaceOptions{
+		Path:         t.TempDir(),
+		MinFreeBytes: 1 << 62,
+	}))
+	readycheck.RegisterComponent("missing", lifecycle.DiskSpaceCheck("missing", lifecycle.DiskSpaceOptions{
+		Path: filepath.Join(t.TempDir(), "missing"),
+	}))
+
+	assert.Equal(map[string]lifecycle.Status{
+		"degraded": lifecycle.StatusDegraded,
+		"full":     lifecycle.StatusNotReady,
+		"missing":  lifecycle.StatusNotReady,
+	}, readycheck.ExplainStatus())
+
+	results := readycheck.ExplainDetailed()
+	assert.ErrorIs(results["full"].Err, lifecycle.ErrLowDiskSpace)
+	assert.Error(results["missing"].Err)
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package lifecycle
+
+func diskUsage(path string) (free uint64, total uint64, err error) {
+	return 0, 0, ErrDiskUsageNotSupported
+}
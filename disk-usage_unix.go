@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd
+
+package lifecycle
+
+import "syscall"
+
+// diskUsage returns the space available to unprivileged users, and the total space of the file system containing the path
+func diskUsage(path string) (free uint64, total uint64, err error) {
+	stat := syscall.Statfs_t{}
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), uint64(stat.Blocks) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,25 @@
+package lifecycle
+
+import "context"
+
+// Drainer is implemented by components which consume messages, such as message broker clients. They are shut down in two
+// phases: the intake is stopped first, then the messages already fetched finish processing.
+type Drainer interface {
+	// StopIntake stops fetching new messages
+	StopIntake(ctx context.Context) error
+	// WaitDrained blocks until the messages already fetched are processed, or until the context is done
+	WaitDrained(ctx context.Context) error
+}
+
+// RegisterDrainer registers a [Drainer] as a component. When signaled, its intake is stopped, then the component waits for it
+// to be drained. Both phases share the remaining shutdown time. If the intake cannot be stopped, the drain is not awaited.
+func (gs *GracefulShutdown) RegisterDrainer(name string, drainer Drainer, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		err := drainer.StopIntake(ctx)
+		if err != nil {
+			return err
+		}
+
+		return drainer.WaitDrained(ctx)
+	}, opts)
+}
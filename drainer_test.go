@@ -0,0 +1,52 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakeDrainer struct {
+	calls     []string
+	intakeErr error
+}
+
+func (d *fakeDrainer) StopIntake(ctx context.Context) error {
+	d.calls = append(d.calls, "StopIntake")
+	return d.intakeErr
+}
+
+func (d *fakeDrainer) WaitDrained(ctx context.Context) error {
+	d.calls = append(d.calls, "WaitDrained")
+	return nil
+}
+
+func Test_WhenDrainerIsRegistered_ShouldStopIntakeBeforeDraining(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	drainer := &fakeDrainer{}
+	assert.NoError(gs.RegisterDrainer("consumer", drainer))
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal([]string{"StopIntake", "WaitDrained"}, drainer.calls)
+}
+
+func Test_WhenDrainerFailsToStopIntake_ShouldNotWaitForDrain(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("connection lost")
+	drainer := &fakeDrainer{intakeErr: expectedErr}
+	assert.NoError(gs.RegisterDrainer("consumer", drainer))
+
+	err := gs.Shutdown()
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.ErrorIs(shutdownErr.ComponentErrors["consumer"], expectedErr)
+	}
+	assert.Equal([]string{"StopIntake"}, drainer.calls)
+}
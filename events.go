@@ -0,0 +1,189 @@
+package lifecycle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var DefaultEventLogSize = 100
+
+// EventType is the kind of an [Event]
+type EventType int
+
+const (
+	// EventComponentRegistered is recorded when a component is registered
+	EventComponentRegistered EventType = iota
+	// EventComponentUnregistered is recorded when a component is unregistered
+	EventComponentUnregistered
+	// EventSignalHandled is recorded when a signal registered with HandleSignal is received
+	EventSignalHandled
+	// EventShutdownRequested is recorded when the shutdown is requested
+	EventShutdownRequested
+	// EventComponentShutdown is recorded when a component is done shutting down, or when it is considered as timed out
+	EventComponentShutdown
+	// EventShutdownFinished is recorded once all components are done shutting down
+	EventShutdownFinished
+	// EventPaused is recorded when the application is paused
+	EventPaused
+	// EventResumed is recorded when the application is resumed
+	EventResumed
+)
+
+// String returns the name of the event type
+func (t EventType) String() string {
+	switch t {
+	case EventComponentRegistered:
+		return "component registered"
+	case EventComponentUnregistered:
+		return "component unregistered"
+	case EventSignalHandled:
+		return "signal handled"
+	case EventShutdownRequested:
+		return "shutdown requested"
+	case EventComponentShutdown:
+		return "component shutdown"
+	case EventShutdownFinished:
+		return "shutdown finished"
+	case EventPaused:
+		return "paused"
+	case EventResumed:
+		return "resumed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is an entry of the lifecycle audit log
+type Event struct {
+	// At is the time at which the event occurred
+	At time.Time
+	// Type is the kind of the event
+	Type EventType
+	// Component is the name of the component the event is about, if any
+	Component string
+	// Signal is the signal which was received, if any. It is set when the shutdown was triggered by a signal.
+	Signal os.Signal
+	// Err is the error reported along with the event, if any. For EventShutdownRequested, it is the shutdown reason.
+	Err error
+	// Duration is the time the component took to shutdown, for EventComponentShutdown
+	Duration time.Duration
+}
+
+// String formats the event as a single line
+func (event Event) String() string {
+	line := fmt.Sprintf("%s %s", event.At.Format(time.RFC3339Nano), event.Type)
+
+	if event.Component != "" {
+		line += fmt.Sprintf(" component=%q", event.Component)
+	}
+
+	if event.Signal != nil {
+		line += fmt.Sprintf(" signal=%q", event.Signal)
+	}
+
+	if event.Type == EventComponentShutdown {
+		line += fmt.Sprintf(" duration=%s", event.Duration)
+	}
+
+	if event.Err != nil {
+		line += fmt.Sprintf(" err=%q", event.Err)
+	}
+
+	return line
+}
+
+// eventLog is a bounded ring buffer of the most recent lifecycle events. It listens to the GracefulShutdown to record them.
+type eventLog struct {
+	mutex  *sync.Mutex
+	clock  Clock
+	events []Event
+	next   int
+	full   bool
+}
+
+func newEventLog(size int, clock Clock) *eventLog {
+	return &eventLog{
+		mutex:  &sync.Mutex{},
+		clock:  clock,
+		events: make([]Event, size),
+	}
+}
+
+func (log *eventLog) record(event Event) {
+	event.At = log.clock.Now()
+
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	log.events[log.next] = event
+	log.next = (log.next + 1) % len(log.events)
+	if log.next == 0 {
+		log.full = true
+	}
+}
+
+// snapshot returns the recorded events, oldest first
+func (log *eventLog) snapshot() []Event {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+
+	if !log.full {
+		return append([]Event{}, log.events[:log.next]...)
+	}
+
+	return append(append([]Event{}, log.events[log.next:]...), log.events[:log.next]...)
+}
+
+func (log *eventLog) ComponentRegistered(name string) {
+	log.record(Event{Type: EventComponentRegistered, Component: name})
+}
+
+func (log *eventLog) ShutdownRequested(reason error) {
+	event := Event{Type: EventShutdownRequested, Err: reason}
+	if signalErr, ok := reason.(SignalError); ok {
+		event.Signal = signalErr.Signal
+	}
+
+	log.record(event)
+}
+
+func (log *eventLog) ComponentShutdownCompleted(name string, err error, duration time.Duration) {
+	log.record(Event{Type: EventComponentShutdown, Component: name, Err: err, Duration: duration})
+}
+
+func (log *eventLog) ShutdownFinished(err error) {
+	log.record(Event{Type: EventShutdownFinished, Err: err})
+}
+
+// recordEvent records an event which is not reported to the listeners
+func (gs *GracefulShutdown) recordEvent(event Event) {
+	if gs.events != nil {
+		gs.events.record(event)
+	}
+}
+
+// Events returns the most recent lifecycle events, oldest first: registrations, handled signals, the shutdown request and its
+// reason, and the outcome of each component. At most [GracefulShutdownOptions.EventLogSize] events are kept.
+func (gs *GracefulShutdown) Events() []Event {
+	if gs.events == nil {
+		return nil
+	}
+
+	return gs.events.snapshot()
+}
+
+// DumpEvents writes the lifecycle events to the writer, one per line, oldest first. It is meant to be used for the post-mortem
+// of a failed shutdown.
+func (gs *GracefulShutdown) DumpEvents(w io.Writer) error {
+	for _, event := range gs.Events() {
+		_, err := fmt.Fprintln(w, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package lifecycle_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func eventTypes(events []lifecycle.Event) []lifecycle.EventType {
+	types := make([]lifecycle.EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+
+	return types
+}
+
+func Test_WhenShutdownFails_ShouldRecordEvents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	failure := errors.New("failed to close")
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", func() error { return failure }))
+	assert.NoError(gs.RegisterComponentWithFn("temporary", func() error { return nil }))
+	assert.NoError(gs.UnregisterComponent("temporary"))
+
+	gs.TriggerShutdownSignal(syscall.SIGTERM)
+	assert.Eventually(func() bool {
+		events := gs.Events()
+		return len(events) > 0 && events[len(events)-1].Type == lifecycle.EventShutdownFinished
+	}, time.Second, time.Millisecond)
+
+	events := gs.Events()
+	assert.Equal([]lifecycle.EventType{
+		lifecycle.EventComponentRegistered,
+		lifecycle.EventComponentRegistered,
+		lifecycle.EventComponentUnregistered,
+		lifecycle.EventShutdownRequested,
+		lifecycle.EventComponentShutdown,
+		lifecycle.EventShutdownFinished,
+	}, eventTypes(events))
+
+	assert.Equal(syscall.SIGTERM, events[3].Signal)
+	assert.Equal("db-pool", events[4].Component)
+	assert.ErrorIs(events[4].Err, failure)
+	assert.False(events[0].At.IsZero())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(gs.DumpEvents(buf))
+	assert.Contains(buf.String(), `component shutdown component="db-pool"`)
+	assert.Contains(buf.String(), `err="failed to close"`)
+	assert.Equal(len(events), bytes.Count(buf.Bytes(), []byte("\n")))
+}
+
+func Test_WhenEventLogIsFull_ShouldKeepMostRecentEvents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		EventLogSize: 2,
+	})
+
+	for _, name := range []string{"a", "b", "c"} {
+		assert.NoError(gs.RegisterComponentWithFn(name, func() error { return nil }))
+	}
+
+	events := gs.Events()
+	if assert.Len(events, 2) {
+		assert.Equal("b", events[0].Component)
+		assert.Equal("c", events[1].Component)
+	}
+}
+
+func Test_WhenEventLogIsDisabled_ShouldNotRecordEvents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		EventLogSize: -1,
+	})
+
+	assert.NoError(gs.RegisterComponentWithFn("a", func() error { return nil }))
+	assert.NoError(gs.Shutdown())
+
+	assert.Empty(gs.Events())
+}
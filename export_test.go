@@ -0,0 +1,11 @@
+package lifecycle
+
+// SetExitFunc replaces the function used to terminate the process and returns a function restoring the original one
+func SetExitFunc(fn func(code int)) func() {
+	original := exit
+	exit = fn
+
+	return func() {
+		exit = original
+	}
+}
@@ -0,0 +1,63 @@
+package lifecycle
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var ErrGateClosed = errors.New("gate was not opened")
+
+// GateComponentCheck is a component check which is not ready until it is explicitly opened, then ready forever. It is meant for
+// the steps which must complete before serving traffic without any check to perform, such as running migrations or warming
+// up a cache. Like the [StartupComponentCheck], it latches once it is ready.
+type GateComponentCheck struct {
+	changeNotifier
+	historyRecorder
+
+	name     string
+	openedAt *atomic.Pointer[time.Time]
+}
+
+// Name is the name of the component being checked for
+func (component *GateComponentCheck) Name() string {
+	return component.name
+}
+
+// Ready returns true once the gate was opened
+func (component *GateComponentCheck) Ready() bool {
+	return component.openedAt.Load() != nil
+}
+
+// Open opens the gate, marking the component as ready. Opening a gate which is already open has no effect.
+func (component *GateComponentCheck) Open() {
+	now := time.Now()
+	if !component.openedAt.CompareAndSwap(nil, &now) {
+		return
+	}
+
+	component.recordHistory(StatusReady, nil, 0)
+	component.notifyChange()
+}
+
+// Result returns the readiness of the component, and the time at which the gate was opened
+func (component *GateComponentCheck) Result() CheckResult {
+	openedAt := loadTime(component.openedAt)
+	if openedAt.IsZero() {
+		return CheckResult{Err: ErrGateClosed, CheckedAt: time.Now()}
+	}
+
+	return CheckResult{Ready: true, CheckedAt: openedAt}
+}
+
+// RegisterGateComponent creates a new closed [GateComponentCheck] and registers it
+func (rdy *ReadyCheck) RegisterGateComponent(name string, opts ...CheckOption) *GateComponentCheck {
+	gateComponent := &GateComponentCheck{
+		name:     name,
+		openedAt: &atomic.Pointer[time.Time]{},
+	}
+
+	rdy.RegisterComponent(name, gateComponent, opts...)
+
+	return gateComponent
+}
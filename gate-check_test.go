@@ -0,0 +1,30 @@
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenGateIsOpened_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readycheck.OnChange(recorder.onChange)
+
+	gate := readycheck.RegisterGateComponent("migrations")
+
+	assert.False(readycheck.Ready())
+	assert.ErrorIs(readycheck.ExplainDetailed()["migrations"].Err, lifecycle.ErrGateClosed)
+
+	gate.Open()
+	gate.Open()
+
+	assert.True(readycheck.Ready())
+	assert.True(readycheck.ExplainDetailed()["migrations"].Ready)
+
+	components, _ := recorder.snapshot()
+	assert.Equal([]string{"migrations:ready"}, components)
+}
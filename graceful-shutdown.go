@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/signal"
+	"runtime"
+	"runtime/debug"
 	"sync"
 	"syscall"
 	"time"
@@ -19,49 +21,284 @@ type GracefulShutdownOptions struct {
 	Timeout time.Duration
 	// PollDuration is the delay between each poll on the shutdown channels
 	//
-	// Default: 100ms
+	// Deprecated: components are no longer polled, their completion is reported as soon as it happens. This option has no effect.
 	PollDuration time.Duration
 
 	// Signals is the array of OS Signal to listen for in WaitForShutdown function
 	//
 	// Default: SIGINT, SIGTERM
 	Signals []os.Signal
+	// SignalNotifier relays the OS Signals to WaitForShutdown. Tests may replace it to inject synthetic signals.
+	//
+	// Default: the os/signal package
+	SignalNotifier SignalNotifier
+
+	// Clock tells the time during the shutdown. Tests may replace it to advance time without sleeping.
+	//
+	// Default: SystemClock
+	Clock Clock
+
+	// PhaseTimeouts is the time budget of each shutdown phase, by phase. The budget of a phase starts when its first component
+	// is signaled, and its components which did not complete once it elapses are reported as timed out, so the next phases
+	// still get their share of the Timeout. The budget is also bounded by the Timeout, and reflected in the deadline of the
+	// context given to the components. Phases without a budget may use the remaining time.
+	//
+	// Default: nil
+	PhaseTimeouts map[int]time.Duration
+
+	// ReverseRegistrationOrder shuts down the components of a phase in the reverse order of their registration, tearing them
+	// down in the reverse order they were constructed in. Each component then waits for the ones registered after it. A
+	// component declaring a dependency on a component registered before it would form a cycle.
+	//
+	// Default: false, the components of a phase are shutdown concurrently
+	ReverseRegistrationOrder bool
+
+	// MaxConcurrentShutdowns caps how many components are shutting down at the same time. The other components are signaled
+	// in registration order as the ones shutting down complete. Components registered with RegisterComponent count towards the limit, but they
+	// observe the AppContext and may therefore start shutting down before being signaled.
+	//
+	// Default: 0, no limit
+	MaxConcurrentShutdowns int
+
+	// FinalizerTimeout is the time given to the finalizers registered with RegisterFinalizer, once the components are done
+	// shutting down
+	//
+	// Default: 5s
+	FinalizerTimeout time.Duration
+
+	// MaxUptime triggers the shutdown once the application has been running for this duration, with [ErrScheduledShutdown] as
+	// its reason. Fleets recycling their instances periodically use it to mitigate slow leaks. The uptime is counted from the
+	// creation of the GracefulShutdown, or from its last Reset.
+	//
+	// Default: 0, no limit
+	MaxUptime time.Duration
+	// MaxUptimeJitter adds a random duration, up to this value, to the MaxUptime, so the instances started together are not all
+	// recycled at once
+	//
+	// Default: 0
+	MaxUptimeJitter time.Duration
+
+	// PreShutdownDelay is the delay between the moment the shutdown is requested and the moment the AppContext is cancelled.
+	// During this delay, the Draining channel is closed so the application can stop advertising itself as ready, giving load
+	// balancers time to stop routing traffic to it before components begin shutting down.
+	//
+	// Default: 0
+	PreShutdownDelay time.Duration
+
+	// ForceExitOnSecondSignal makes the process exit immediately when a second signal is received while the graceful shutdown
+	// triggered by WaitForShutdown is still in progress
+	//
+	// Default: false
+	ForceExitOnSecondSignal bool
+	// ForceExitCode is the exit code used when the process is forced to exit
+	//
+	// Default: 1
+	ForceExitCode int
+
+	// DumpGoroutinesOnTimeout captures the stack of all goroutines when a component times out. The dump is attached to the
+	// ShutdownError and passed to OnGoroutineDump.
+	//
+	// Default: false
+	DumpGoroutinesOnTimeout bool
+	// OnGoroutineDump is invoked with the goroutine dump captured when DumpGoroutinesOnTimeout is enabled
+	//
+	// Default: nil
+	OnGoroutineDump func(dump []byte)
+
+	// OnSignalError is invoked when a handler registered with HandleSignal returns an error
+	//
+	// Default: nil
+	OnSignalError func(sig os.Signal, err error)
+
+	// ErrorOnNoComponents makes the shutdown return [ErrNoComponents] when no component is registered, surfacing wiring bugs
+	// where the registrations never ran. The AppContext is still cancelled and the hooks still run.
+	//
+	// Default: false
+	ErrorOnNoComponents bool
+	// OnNoComponents is invoked when the shutdown begins while no component is registered, for instance to log a warning
+	//
+	// Default: nil
+	OnNoComponents func()
+
+	// DetectGoroutineLeaks captures the goroutines running when the GracefulShutdown is created, or Reset, and reports in
+	// [ShutdownReport.Leaks] the goroutines started since which are still running once the components are done shutting down.
+	// It is mostly useful in test suites exercising the full lifecycle. Goroutines started concurrently by unrelated code, such
+	// as parallel tests, are reported as well.
+	//
+	// Default: false
+	DetectGoroutineLeaks bool
+	// GoroutineLeakGracePeriod is the time given to the goroutines to exit once the components are done shutting down, before
+	// they are reported as leaked
+	//
+	// Default: 1s
+	GoroutineLeakGracePeriod time.Duration
+	// IgnoreGoroutines excludes from the leak detection the goroutines whose stack contains any of these strings, such as the
+	// name of a function
+	//
+	// Default: nil
+	IgnoreGoroutines []string
+
+	// EventLogSize is the number of lifecycle events kept in memory. See [GracefulShutdown.Events]. A negative size disables the
+	// event log.
+	//
+	// Default: DefaultEventLogSize
+	EventLogSize int
+
+	// FailureExitCode is the exit code used by RunUntilShutdown when a component failed to shutdown
+	//
+	// Default: 1
+	FailureExitCode int
+	// TimeoutExitCode is the exit code used by RunUntilShutdown when components did not shutdown in time
+	//
+	// Default: 1
+	TimeoutExitCode int
 }
 
 // GracefulShutdown is an utility that allows you to perform graceful shutdowns on different components of your application.
 type GracefulShutdown struct {
 	componentMutex *sync.RWMutex
 	waitMutex      *sync.Mutex
+	hooksMutex     *sync.RWMutex
+	signalMutex    *sync.RWMutex
+
+	options       GracefulShutdownOptions
+	parentContext context.Context
+	appContext    context.Context
+	shutdownFunc  context.CancelCauseFunc
+
+	draining     chan struct{}
+	drainingOnce *sync.Once
+	outcome      *shutdownOutcome
+	pause        *pauseState
+
+	components      map[string]*component
+	registrations   uint64
+	deferred        []string
+	groups          map[string]*ComponentGroup
+	progressTracker *progressTracker
+	state           *stateMachine
+
+	goroutineBaseline map[uint64]bool
+
+	startHooks    []func()
+	completeHooks []func(err error)
+	timeoutHooks  []func(pending []string)
+	finalizers    []func(ctx context.Context) error
+	listeners     []Listener
+	readyChecks   []*ReadyCheck
+	events        *eventLog
+
+	signals          chan os.Signal
+	signalHandlers   map[os.Signal]SignalHandler
+	shutdownRequests chan error
+
+	shuttingDown bool
+	disposed     bool
+}
+
+type componentResult struct {
+	name string
+	err  error
+}
 
-	options      GracefulShutdownOptions
-	appContext   context.Context
-	shutdownFunc func()
+type component struct {
+	name      string
+	phase     int
+	dependsOn []string
+	group     string
+	// order is the position of the component in the registration order
+	order uint64
+	// deferred is true for the components registered with Defer, which wait for the components deferred after them
+	deferred bool
+	// readyChecks are the ReadyChecks in which the component reports its readiness, through the readiness push checks
+	readyChecks []*ReadyCheck
+	readiness   []*PushComponentCheck
 
-	components map[string]<-chan error
+	// start receives the shutdown context when the component is expected to begin its shutdown. It is nil for components
+	// registered through RegisterComponent, since those rely on the AppContext instead.
+	start        chan context.Context
+	shutdownChan <-chan error
+	// unregistered is closed when the component is removed from the GracefulShutdown
+	unregistered chan struct{}
+	// abandoned is closed when the shutdown timed out before the component was signaled. It is nil when start is nil.
+	abandoned chan struct{}
+}
+
+// ComponentOption configures how a component takes part in the shutdown process
+type ComponentOption func(c *component)
+
+// DependsOn declares that the component may only start shutting down once all the given components are done shutting down.
+// Dependencies that are not registered by the time the shutdown occurs are ignored.
+func DependsOn(names ...string) ComponentOption {
+	return func(c *component) {
+		c.dependsOn = append(c.dependsOn, names...)
+	}
+}
+
+// After declares that the component drains after the given components. It is equivalent to [DependsOn], and reads more naturally
+// when only one or two ordering constraints matter.
+func After(names ...string) ComponentOption {
+	return DependsOn(names...)
+}
+
+// InPhase sets the shutdown phase of the component. This is mostly useful with registration methods which do not take a phase,
+// such as [GracefulShutdown.RegisterHTTPServer].
+func InPhase(phase int) ComponentOption {
+	return func(c *component) {
+		c.phase = phase
+	}
+}
 
-	disposed bool
+// ReportReadiness reports the component's readiness in the given [ReadyCheck], through a push component named after it. The
+// component is reported as ready once registered, and as not ready as soon as it is signaled to shutdown, so a server stops
+// being advertised when it starts draining. The push component is unregistered along with the component.
+func ReportReadiness(rdy *ReadyCheck) ComponentOption {
+	return func(c *component) {
+		c.readyChecks = append(c.readyChecks, rdy)
+	}
 }
 
 // ShutdownChan is a Producer channel used to report an error in the Shutdown process
 type ShutdownChan = chan<- error
 
+// DefaultPhase is the phase in which components are registered when no phase is specified
+const DefaultPhase = 0
+
 var (
-	DefaultTimeout      = 5 * time.Second
-	DefaultPollDuration = 100 * time.Millisecond
-	DefaultSignals      = []os.Signal{
+	DefaultTimeout          = 5 * time.Second
+	DefaultPollDuration     = 100 * time.Millisecond
+	DefaultForceExitCode    = 1
+	DefaultFailureExitCode  = 1
+	DefaultTimeoutExitCode  = 1
+	DefaultFinalizerTimeout = 5 * time.Second
+	DefaultSignals          = []os.Signal{
 		os.Interrupt,
 		syscall.SIGTERM,
 	}
 
+	DefaultGoroutineLeakGracePeriod = time.Second
+
 	ErrComponentAlreadyRegistered = errors.New("component was already registered")
+	ErrComponentNotRegistered     = errors.New("component is not registered")
+	ErrDependencyCycle            = errors.New("component dependencies form a cycle")
+	ErrGroupNotFound              = errors.New("group does not exist")
 	ErrAlreadyShutdown            = errors.New("shutdown has already occurred")
+	ErrShutdownNotCompleted       = errors.New("shutdown has not completed")
 	ErrAlreadyWaitingForShutdown  = errors.New("shutdown is being awaited for")
 	ErrShutdownTimeout            = errors.New("shutdown took too long to complete")
+	ErrShutdownRequested          = errors.New("shutdown was requested")
+	ErrComponentPanicked          = errors.New("component panicked while shutting down")
+	ErrFinalizerFailed            = errors.New("finalizer failed")
+	ErrScheduledShutdown          = errors.New("scheduled shutdown time was reached")
+	ErrNoComponents               = errors.New("no component was registered")
 )
 
+// exit terminates the process. It is a variable so tests can intercept it.
+var exit = os.Exit
+
 // NewGracefulShutdownWithOptions creates a new instance of [*GracefulShutdown]. You may provide a [context.Context] to enable Context Cancellation, as well as behaviour options.
 func NewGracefulShutdownWithOptions(ctx context.Context, options GracefulShutdownOptions) *GracefulShutdown {
-	appCtx, cancel := context.WithCancel(ctx)
+	appCtx, cancel := context.WithCancelCause(ctx)
 
 	if options.Timeout == 0 {
 		options.Timeout = DefaultTimeout
@@ -75,16 +312,72 @@ func NewGracefulShutdownWithOptions(ctx context.Context, options GracefulShutdow
 		options.Signals = DefaultSignals
 	}
 
-	return &GracefulShutdown{
+	if options.SignalNotifier == nil {
+		options.SignalNotifier = OSSignalNotifier
+	}
+
+	if options.Clock == nil {
+		options.Clock = SystemClock
+	}
+
+	if options.FinalizerTimeout == 0 {
+		options.FinalizerTimeout = DefaultFinalizerTimeout
+	}
+
+	if options.GoroutineLeakGracePeriod == 0 {
+		options.GoroutineLeakGracePeriod = DefaultGoroutineLeakGracePeriod
+	}
+
+	if options.EventLogSize == 0 {
+		options.EventLogSize = DefaultEventLogSize
+	}
+
+	if options.ForceExitCode == 0 {
+		options.ForceExitCode = DefaultForceExitCode
+	}
+
+	if options.FailureExitCode == 0 {
+		options.FailureExitCode = DefaultFailureExitCode
+	}
+
+	if options.TimeoutExitCode == 0 {
+		options.TimeoutExitCode = DefaultTimeoutExitCode
+	}
+
+	gs := &GracefulShutdown{
 		componentMutex: &sync.RWMutex{},
 		waitMutex:      &sync.Mutex{},
+		hooksMutex:     &sync.RWMutex{},
+		signalMutex:    &sync.RWMutex{},
 
-		options:      options,
-		appContext:   appCtx,
-		shutdownFunc: cancel,
+		options:       options,
+		parentContext: ctx,
+		appContext:    appCtx,
+		shutdownFunc:  cancel,
 
-		components: make(map[string]<-chan error),
+		draining:     make(chan struct{}),
+		drainingOnce: &sync.Once{},
+		outcome:      newShutdownOutcome(),
+		pause:        newPauseState(appCtx),
+
+		components:      make(map[string]*component),
+		groups:          make(map[string]*ComponentGroup),
+		progressTracker: newProgressTracker(options.Clock),
+		state:           newStateMachine(StateRunning),
+
+		signalHandlers:   make(map[os.Signal]SignalHandler),
+		shutdownRequests: make(chan error, 1),
+	}
+
+	if options.EventLogSize > 0 {
+		gs.events = newEventLog(options.EventLogSize, options.Clock)
+		gs.listeners = append(gs.listeners, gs.events)
 	}
+
+	gs.captureGoroutineBaseline()
+	gs.scheduleMaxUptime()
+
+	return gs
 }
 
 // NewGracefulShutdown creates a new instance of [*GracefulShutdown]. You may provide a [context.Context] to enable Context Cancellation. Default options will be used.
@@ -98,11 +391,159 @@ func NewGracefulShutdown(ctx context.Context) *GracefulShutdown {
 	return gs
 }
 
+// Reset makes a GracefulShutdown which was shutdown reusable. A new AppContext is created from the context given at creation,
+// and all the components are unregistered. Groups, hooks and listeners are kept.
+//
+// Reset returns a [ErrShutdownNotCompleted] error if the shutdown has not completed yet. It must not be called concurrently
+// with the other methods of the GracefulShutdown.
+func (gs *GracefulShutdown) Reset() error {
+	gs.componentMutex.Lock()
+	defer gs.componentMutex.Unlock()
+
+	if !gs.disposed {
+		return ErrShutdownNotCompleted
+	}
+
+	appCtx, cancel := context.WithCancelCause(gs.parentContext)
+	gs.appContext = appCtx
+	gs.shutdownFunc = cancel
+
+	gs.draining = make(chan struct{})
+	gs.drainingOnce = &sync.Once{}
+	gs.outcome = newShutdownOutcome()
+	gs.pause = newPauseState(appCtx)
+	gs.state.reset(StateRunning)
+	gs.setReadyChecksDraining(false)
+
+	select {
+	case <-gs.shutdownRequests:
+	default:
+	}
+
+	for _, c := range gs.components {
+		close(c.unregistered)
+		c.releaseReadiness()
+	}
+	gs.components = make(map[string]*component)
+	gs.deferred = nil
+
+	gs.disposed = false
+	gs.captureGoroutineBaseline()
+	gs.scheduleMaxUptime()
+
+	return nil
+}
+
 // AppContext is the GracefulShutdown's context. Use its Done method to determine if the shutdown was requested or not.
 func (gs *GracefulShutdown) AppContext() context.Context {
 	return gs.appContext
 }
 
+// Draining returns a channel which is closed as soon as the shutdown is requested, before the [GracefulShutdownOptions.PreShutdownDelay]
+// elapses and the AppContext is cancelled. Use it to stop accepting new traffic, for instance by reporting the application as not ready.
+func (gs *GracefulShutdown) Draining() <-chan struct{} {
+	return gs.draining
+}
+
+// ShutdownReason returns why the shutdown was triggered, or nil if it was not triggered yet. It is either:
+//   - a [SignalError] when the shutdown was triggered by an OS Signal in WaitForShutdown;
+//   - [ErrShutdownRequested] when the shutdown was triggered by calling Shutdown or ShutdownWithContext;
+//   - the cause of the parent context when it was cancelled.
+//
+// The reason is also available to components through `context.Cause(gs.AppContext())`.
+func (gs *GracefulShutdown) ShutdownReason() error {
+	return context.Cause(gs.appContext)
+}
+
+// OnShutdownStart registers a hook invoked once, as soon as the shutdown is requested and before any component is signaled
+func (gs *GracefulShutdown) OnShutdownStart(hook func()) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.startHooks = append(gs.startHooks, hook)
+}
+
+// OnShutdownComplete registers a hook invoked once all components are done shutting down, or once the shutdown timed out.
+// The hook receives the error returned by the shutdown, if any.
+func (gs *GracefulShutdown) OnShutdownComplete(hook func(err error)) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.completeHooks = append(gs.completeHooks, hook)
+}
+
+// OnShutdownTimeout registers a hook invoked when the shutdown times out, before Shutdown returns. The hook receives the sorted
+// names of the components which did not complete their shutdown, or were never signaled, so that a targeted alert can be raised.
+func (gs *GracefulShutdown) OnShutdownTimeout(hook func(pending []string)) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.timeoutHooks = append(gs.timeoutHooks, hook)
+}
+
+// RegisterFinalizer registers a function invoked once all the components are done shutting down, or timed out, and once the
+// hooks and listeners were notified. Finalizers are meant to flush loggers, traces and metrics exporters, which must not race
+// with components still emitting telemetry.
+//
+// Finalizers are invoked one at a time, in registration order. They share the [GracefulShutdownOptions.FinalizerTimeout],
+// which the context reflects. Their errors are wrapped in [ErrFinalizerFailed] and joined to the error of the shutdown.
+func (gs *GracefulShutdown) RegisterFinalizer(fn func(ctx context.Context) error) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.finalizers = append(gs.finalizers, fn)
+}
+
+func (gs *GracefulShutdown) runFinalizers() error {
+	gs.hooksMutex.RLock()
+	finalizers := append([]func(ctx context.Context) error{}, gs.finalizers...)
+	gs.hooksMutex.RUnlock()
+
+	if len(finalizers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withTimeout(context.Background(), gs.options.Clock, gs.options.FinalizerTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, finalizer := range finalizers {
+		err := callShutdownFn(ctx, finalizer)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrFinalizerFailed, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AddListener registers a [Listener] which will be notified of the lifecycle events
+func (gs *GracefulShutdown) AddListener(listener Listener) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.listeners = append(gs.listeners, listener)
+}
+
+// AttachReadyCheck puts the [ReadyCheck] in drain mode as soon as the shutdown is requested, before the components begin
+// draining. The ReadyCheck then reports the application as not ready, so it stops receiving new traffic during the drain.
+// [GracefulShutdown.Reset] takes the ReadyCheck out of drain mode.
+func (gs *GracefulShutdown) AttachReadyCheck(rdy *ReadyCheck) {
+	gs.hooksMutex.Lock()
+	defer gs.hooksMutex.Unlock()
+
+	gs.readyChecks = append(gs.readyChecks, rdy)
+}
+
+func (gs *GracefulShutdown) setReadyChecksDraining(draining bool) {
+	gs.hooksMutex.RLock()
+	defer gs.hooksMutex.RUnlock()
+
+	for _, rdy := range gs.readyChecks {
+		rdy.SetDraining(draining)
+	}
+}
+
 // RegisteredComponents returns the list of registered components
 func (gs *GracefulShutdown) RegisteredComponents() []string {
 	gs.componentMutex.RLock()
@@ -120,152 +561,619 @@ func (gs *GracefulShutdown) RegisteredComponents() []string {
 
 // RegisterComponent registers a component and return a [ShutdownChan]. Used in conjucture with `*GracefulShutdown.AppContext().Done()`,
 // it allows you to report when the shutdown is done and report an optional error if the component failed to gracefully shutdown.
-func (gs *GracefulShutdown) RegisterComponent(name string) (ShutdownChan, error) {
-	gs.componentMutex.Lock()
-	defer gs.componentMutex.Unlock()
-
-	shutdownChan := make(chan error)
+//
+// The component is part of the [DefaultPhase]. Since it is signaled through the AppContext, it starts shutting down as soon as the
+// shutdown is requested, but it is only awaited along with the other components of its phase and after its dependencies.
+// Use [GracefulShutdown.RegisterComponentWithFn] when the component itself must wait for other components.
+//
+// Registering a component whose dependencies would form a cycle returns an [ErrDependencyCycle] error. Registering a component
+// once the shutdown has occurred returns a [ErrAlreadyShutdown] error.
+func (gs *GracefulShutdown) RegisterComponent(name string, opts ...ComponentOption) (ShutdownChan, error) {
+	// Buffered, so reporting never blocks once the component is no longer awaited, such as after a timeout
+	shutdownChan := make(chan error, 1)
 
-	if _, ok := gs.components[name]; ok {
-		return nil, ErrComponentAlreadyRegistered
+	err := gs.addComponent(&component{
+		name:         name,
+		phase:        DefaultPhase,
+		shutdownChan: shutdownChan,
+		unregistered: make(chan struct{}),
+	}, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	gs.components[name] = shutdownChan
-
 	return shutdownChan, nil
 }
 
 // RegisterComponentWithFn registers a component using a function in parameter. This is a simplified way of using the registration, especially for
-// simpler cases.
-func (gs *GracefulShutdown) RegisterComponentWithFn(name string, shutdownFn func() error) error {
-	shutdownChan, err := gs.RegisterComponent(name)
+// simpler cases. The component is part of the [DefaultPhase].
+func (gs *GracefulShutdown) RegisterComponentWithFn(name string, shutdownFn func() error, opts ...ComponentOption) error {
+	return gs.RegisterComponentInPhase(name, DefaultPhase, shutdownFn, opts...)
+}
+
+// RegisterComponentInPhase registers a component using a function in parameter, within the given shutdown phase. Phases are drained
+// in descending order: every component of a phase must be done shutting down before the components of the next (lower) phase are
+// signaled. Components within the same phase are shutdown concurrently, unless they declared dependencies with [DependsOn].
+func (gs *GracefulShutdown) RegisterComponentInPhase(name string, phase int, shutdownFn func() error, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, phase, func(context.Context) error {
+		return shutdownFn()
+	}, opts)
+}
+
+// registerComponentFn registers a component whose shutdown function receives the shutdown context, bounded by the shutdown deadline
+func (gs *GracefulShutdown) registerComponentFn(name string, phase int, shutdownFn func(ctx context.Context) error, opts []ComponentOption) error {
+	// Buffered, so the goroutine exits even when the component is no longer awaited, such as after a timeout
+	shutdownChan := make(chan error, 1)
+	start := make(chan context.Context, 1)
+	unregistered := make(chan struct{})
+	abandoned := make(chan struct{})
+
+	err := gs.addComponent(&component{
+		name:         name,
+		phase:        phase,
+		start:        start,
+		shutdownChan: shutdownChan,
+		unregistered: unregistered,
+		abandoned:    abandoned,
+	}, opts)
 	if err != nil {
 		return err
 	}
 
 	go func() {
-		// Waiting for the Graceful shutdown to be requested
-		<-gs.appContext.Done()
+		// Waiting for the component's turn to be signaled
+		var ctx context.Context
+		select {
+		case ctx = <-start:
+		case <-unregistered:
+			return
+		case <-abandoned:
+			return
+		}
 
-		err := shutdownFn()
+		err := callShutdownFn(ctx, shutdownFn)
 		shutdownChan <- err
 	}()
 
 	return nil
 }
 
+// RegisterComponentWithContext registers a component using a function in parameter, like [GracefulShutdown.RegisterComponentWithFn].
+// The function receives the shutdown context, whose deadline is the time the component has left to shutdown, accounting for the
+// phase and group budgets. A drain can use it to skip optional steps when time is short. See also [GracefulShutdown.Remaining].
+func (gs *GracefulShutdown) RegisterComponentWithContext(name string, shutdownFn func(ctx context.Context) error, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, shutdownFn, opts)
+}
+
+// RegisterCloser registers an [io.Closer] as a component. The closer is closed when the component is signaled.
+func (gs *GracefulShutdown) RegisterCloser(name string, closer io.Closer, opts ...ComponentOption) error {
+	return gs.RegisterComponentWithFn(name, closer.Close, opts...)
+}
+
+// RegisterChild registers another [GracefulShutdown] as a component. When signaled, the child is shutdown with the remaining
+// shutdown time and the same shutdown reason, which cancels its AppContext and drains its own components. If the child fails to
+// shutdown gracefully, its [ShutdownError] is reported as the component's error.
+func (gs *GracefulShutdown) RegisterChild(name string, child *GracefulShutdown, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		return child.shutdown(ctx, context.Cause(gs.appContext))
+	}, opts)
+}
+
+// reportNotReady reports the component as not ready, once it is signaled to shutdown
+func (c *component) reportNotReady() {
+	for _, readiness := range c.readiness {
+		readiness.SetReady(false)
+	}
+}
+
+// releaseReadiness unregisters the readiness push checks of the component, leaving the other checks registered with its name
+func (c *component) releaseReadiness() {
+	for i, rdy := range c.readyChecks {
+		rdy.unregisterCheck(c.readiness[i])
+	}
+}
+
+// callShutdownFn invokes the shutdown function, converting a panic into a [PanicError]
+func callShutdownFn(ctx context.Context, shutdownFn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = PanicError{
+				Value: value,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
+	return shutdownFn(ctx)
+}
+
+func (gs *GracefulShutdown) addComponent(c *component, opts []ComponentOption) error {
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// The readiness checks are created beforehand, since the component may be signaled as soon as it is inserted
+	for range c.readyChecks {
+		readiness := newPushComponentCheck(c.name)
+		readiness.SetReady(true)
+
+		c.readiness = append(c.readiness, readiness)
+	}
+
+	err := gs.insertComponent(c)
+	if err != nil {
+		return err
+	}
+
+	for i, rdy := range c.readyChecks {
+		rdy.RegisterComponent(c.name, c.readiness[i])
+	}
+
+	return nil
+}
+
+// insertComponent validates the component and adds it to the registered components
+func (gs *GracefulShutdown) insertComponent(c *component) error {
+	gs.componentMutex.Lock()
+	defer gs.componentMutex.Unlock()
+
+	if gs.shuttingDown || gs.disposed {
+		return ErrAlreadyShutdown
+	}
+
+	if _, ok := gs.components[c.name]; ok {
+		return ErrComponentAlreadyRegistered
+	}
+
+	if c.group != "" {
+		group, ok := gs.groups[c.group]
+		if !ok {
+			return ErrGroupNotFound
+		}
+
+		c.phase = group.options.Phase
+	}
+
+	gs.registrations++
+	c.order = gs.registrations
+	gs.components[c.name] = c
+
+	// The most recent deferred component which is still registered must wait for this one
+	var previous *component
+	if c.deferred {
+		previous = gs.lastDeferred()
+		if previous != nil {
+			previous.dependsOn = append(previous.dependsOn, c.name)
+		}
+	}
+
+	reverse := gs.options.ReverseRegistrationOrder
+	if dependsOn(gs.components, c, c.name, make(map[string]bool), reverse) ||
+		(previous != nil && dependsOn(gs.components, previous, previous.name, make(map[string]bool), reverse)) {
+		delete(gs.components, c.name)
+		if previous != nil {
+			previous.dependsOn = previous.dependsOn[:len(previous.dependsOn)-1]
+		}
+
+		return ErrDependencyCycle
+	}
+
+	if c.deferred {
+		gs.deferred = append(gs.deferred, c.name)
+	}
+
+	gs.notify(func(listener Listener) {
+		listener.ComponentRegistered(c.name)
+	})
+
+	return nil
+}
+
+// UnregisterComponent removes a previously registered component. The component will no longer be awaited during the shutdown.
+// Components depending on it are no longer held back by it.
+//
+// Unregistering an unknown component returns a [ErrComponentNotRegistered] error. Once the shutdown has occurred, a
+// [ErrAlreadyShutdown] error is returned.
+func (gs *GracefulShutdown) UnregisterComponent(name string) error {
+	gs.componentMutex.Lock()
+	defer gs.componentMutex.Unlock()
+
+	if gs.shuttingDown || gs.disposed {
+		return ErrAlreadyShutdown
+	}
+
+	c, ok := gs.components[name]
+	if !ok {
+		return ErrComponentNotRegistered
+	}
+
+	delete(gs.components, name)
+	close(c.unregistered)
+	c.releaseReadiness()
+
+	gs.recordEvent(Event{Type: EventComponentUnregistered, Component: name})
+
+	return nil
+}
+
 // Shutdown will trigger the graceful shutdown process. The AppContext will be considered done, and each component will be expected to shutdown
 // within the allocated time period. If any component fails to do so, the error will be reported as a return value.
 //
-// Invoking Shutdown multiple times will return a [ErrAlreadyShutdown] error.
+// Invoking Shutdown while a shutdown is in progress waits for it to complete, and returns its result. Invoking Shutdown once the
+// shutdown completed returns a [ErrAlreadyShutdown] error.
 func (gs *GracefulShutdown) Shutdown() error {
-	gs.shutdownFunc()
+	return gs.ShutdownWithContext(context.Background())
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), gs.options.Timeout)
-	defer cancel()
+// ShutdownWithContext triggers the graceful shutdown process, like [GracefulShutdown.Shutdown], but the time allocated to the components
+// is bounded by the given context. When the context has a deadline, it replaces the configured Timeout. Otherwise, the configured
+// Timeout still applies. Components which did not complete when the context is done are reported with a [ErrShutdownTimeout] error.
+func (gs *GracefulShutdown) ShutdownWithContext(ctx context.Context) error {
+	return gs.shutdown(ctx, ErrShutdownRequested)
+}
+
+func (gs *GracefulShutdown) shutdown(ctx context.Context, reason error) error {
+	if !gs.startDraining() {
+		return gs.outcome.wait(ctx)
+	}
+
+	gs.state.transition(StateDraining)
+	gs.setReadyChecksDraining(true)
+	gs.notify(func(listener Listener) {
+		listener.ShutdownRequested(reason)
+	})
+	gs.runStartHooks()
+
+	if gs.options.PreShutdownDelay > 0 {
+		select {
+		case <-gs.options.Clock.After(gs.options.PreShutdownDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		deadline = gs.options.Clock.Now().Add(gs.options.Timeout)
+
+		var cancel context.CancelFunc
+		ctx, cancel = withTimeout(ctx, gs.options.Clock, gs.options.Timeout)
+		defer cancel()
+	}
+
+	gs.progressTracker.setDeadline(deadline)
+
+	gs.shutdownFunc(reason)
 
 	err := gs.waitForComponents(ctx)
+
+	if err != nil {
+		gs.state.transition(StateFailed)
+	} else {
+		gs.state.transition(StateStopped)
+	}
+
+	gs.runCompleteHooks(err)
+	gs.notify(func(listener Listener) {
+		listener.ShutdownFinished(err)
+	})
+
+	finalizerErr := gs.runFinalizers()
+	if finalizerErr != nil {
+		err = errors.Join(err, finalizerErr)
+	}
+
+	gs.outcome.complete(err)
+
 	return err
 }
 
-// WaitForShutdown blocks until the configured OS Signal is received. Once it is received, the graceful shutdown process will be triggered.
+// shutdownOutcome is the result of the shutdown, shared with the callers which requested the shutdown while it was in progress
+type shutdownOutcome struct {
+	done chan struct{}
+	err  error
+}
+
+func newShutdownOutcome() *shutdownOutcome {
+	return &shutdownOutcome{
+		done: make(chan struct{}),
+	}
+}
+
+func (outcome *shutdownOutcome) complete(err error) {
+	outcome.err = err
+	close(outcome.done)
+}
+
+// wait returns the result of the shutdown in progress once it completes, or the context's error if it is done first. Once the
+// shutdown completed, it returns a [ErrAlreadyShutdown] error.
+func (outcome *shutdownOutcome) wait(ctx context.Context) error {
+	select {
+	case <-outcome.done:
+		return ErrAlreadyShutdown
+	default:
+	}
+
+	select {
+	case <-outcome.done:
+		return outcome.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// result blocks until the shutdown completes and returns its error
+func (outcome *shutdownOutcome) result() error {
+	<-outcome.done
+	return outcome.err
+}
+
+func (gs *GracefulShutdown) notify(event func(listener Listener)) {
+	gs.hooksMutex.RLock()
+	defer gs.hooksMutex.RUnlock()
+
+	for _, listener := range gs.listeners {
+		event(listener)
+	}
+}
+
+func (gs *GracefulShutdown) runStartHooks() {
+	gs.hooksMutex.RLock()
+	defer gs.hooksMutex.RUnlock()
+
+	for _, hook := range gs.startHooks {
+		hook()
+	}
+}
+
+func (gs *GracefulShutdown) runCompleteHooks(err error) {
+	gs.hooksMutex.RLock()
+	defer gs.hooksMutex.RUnlock()
+
+	for _, hook := range gs.completeHooks {
+		hook(err)
+	}
+}
+
+func (gs *GracefulShutdown) runTimeoutHooks(pending []string) {
+	gs.hooksMutex.RLock()
+	defer gs.hooksMutex.RUnlock()
+
+	for _, hook := range gs.timeoutHooks {
+		hook(pending)
+	}
+}
+
+// WaitForShutdown blocks until the configured OS Signal is received, or until a runner fails. Once it happens, the graceful shutdown process will be triggered.
 // Each component will be expected to shutdown within the allocated time period. If any component fails to do so, the error will be reported as a return value.
 //
 // Invoking this method multiple times will return a [ErrAlreadyWaitingForShutdown] error to be returned.
 //
 // Invoking this method after the application was shutdown already will cause a [ErrAlreadyShutdown] error.
+//
+// Signals registered with [GracefulShutdown.HandleSignal] invoke their handler instead of triggering the shutdown.
+//
+// When [GracefulShutdownOptions.ForceExitOnSecondSignal] is enabled, receiving another signal while the shutdown is in progress
+// exits the process immediately with the configured [GracefulShutdownOptions.ForceExitCode].
 func (gs *GracefulShutdown) WaitForShutdown() error {
 	success := gs.waitMutex.TryLock()
-	defer gs.waitMutex.Unlock()
 	if !success {
 		return ErrAlreadyWaitingForShutdown
 	}
+	defer gs.waitMutex.Unlock()
 
-	if gs.disposed {
-		return ErrAlreadyShutdown
+	if gs.isDisposed() {
+		select {
+		case <-gs.shutdownRequests:
+			// A runner requested the shutdown, which completed before WaitForShutdown was invoked
+			return gs.outcome.result()
+		default:
+			return ErrAlreadyShutdown
+		}
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), gs.options.Signals...)
-	defer cancel()
+	signals := make(chan os.Signal, 1)
+	gs.subscribeSignals(signals)
+	defer gs.unsubscribeSignals()
+
+	reason := gs.nextShutdownReason(signals)
 
-	<-ctx.Done()
+	// Signals keep being dispatched while the shutdown is in progress
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if gs.dispatchSignal(sig) || !gs.options.ForceExitOnSecondSignal {
+					continue
+				}
+
+				exit(gs.options.ForceExitCode)
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := gs.shutdown(context.Background(), reason)
+	if errors.Is(err, ErrAlreadyShutdown) {
+		// The shutdown started elsewhere completed before it could be joined
+		return gs.outcome.result()
+	}
 
-	err := gs.Shutdown()
 	return err
 }
 
+// startDraining closes the Draining channel. It returns true the first time it is called.
+func (gs *GracefulShutdown) startDraining() bool {
+	started := false
+
+	gs.drainingOnce.Do(func() {
+		close(gs.draining)
+		started = true
+	})
+
+	return started
+}
+
+// RunUntilShutdown blocks until the shutdown is complete, as WaitForShutdown does, then exits the process with the exit code
+// matching the outcome of the shutdown. See [GracefulShutdown.ExitCode]. The shutdown hooks and listeners are all invoked before
+// the process exits.
+func (gs *GracefulShutdown) RunUntilShutdown() {
+	err := gs.WaitForShutdown()
+	exit(gs.ExitCode(err))
+}
+
+// ExitCode maps the error returned by a shutdown to a process exit code:
+//   - 0 when the shutdown succeeded;
+//   - [GracefulShutdownOptions.TimeoutExitCode] when all the component errors are timeouts;
+//   - [GracefulShutdownOptions.FailureExitCode] otherwise.
+func (gs *GracefulShutdown) ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	shutdownErr := ShutdownError{}
+	if errors.As(err, &shutdownErr) && shutdownErr.IsTimeoutErr() {
+		return gs.options.TimeoutExitCode
+	}
+
+	return gs.options.FailureExitCode
+}
+
 func (gs *GracefulShutdown) waitForComponents(ctx context.Context) error {
+	snapshot, err := gs.beginDrain()
+	if err != nil {
+		return err
+	}
+	defer gs.endDrain()
+
+	noComponents := len(snapshot.components) == 0
+	if noComponents && gs.options.OnNoComponents != nil {
+		gs.options.OnNoComponents()
+	}
+
+	err = gs.drain(ctx, snapshot)
+	if err == nil && noComponents && gs.options.ErrorOnNoComponents {
+		return ErrNoComponents
+	}
+
+	return err
+}
+
+// beginDrain flags the GracefulShutdown as shutting down and takes a snapshot of the registered components. The component lock
+// is not held during the drain, so the read APIs remain responsive.
+func (gs *GracefulShutdown) beginDrain() (drainSnapshot, error) {
 	gs.componentMutex.Lock()
 	defer gs.componentMutex.Unlock()
 
-	// Disposing of the GracefulShutdown instance
-	defer func() {
-		gs.disposed = true
-	}()
+	if gs.shuttingDown || gs.disposed {
+		return drainSnapshot{}, ErrAlreadyShutdown
+	}
 
-	if gs.disposed {
-		return ErrAlreadyShutdown
+	gs.shuttingDown = true
+
+	snapshot := drainSnapshot{
+		components: make(map[string]*component, len(gs.components)),
+		groups:     make(map[string]GroupOptions, len(gs.groups)),
 	}
 
-	componentErrors := make(map[string]error)
+	for componentName, c := range gs.components {
+		snapshot.components[componentName] = c
+	}
 
-	remainingComponents := make(map[string]<-chan error, len(gs.components))
-	for componentName, channel := range gs.components {
-		remainingComponents[componentName] = channel
+	for groupName, group := range gs.groups {
+		snapshot.groups[groupName] = group.options
 	}
 
-	for {
-		// Check for timeout
-		select {
-		case <-ctx.Done():
-			for componentName := range remainingComponents {
-				componentErrors[componentName] = ErrShutdownTimeout
-			}
+	return snapshot, nil
+}
 
-			return ShutdownError{
-				ComponentErrors: componentErrors,
-			}
-		default:
-		}
+// isDisposed returns true once the GracefulShutdown instance was shutdown
+func (gs *GracefulShutdown) isDisposed() bool {
+	gs.componentMutex.RLock()
+	defer gs.componentMutex.RUnlock()
 
-		futureRemComponents := make(map[string]<-chan error, len(remainingComponents))
+	return gs.disposed
+}
 
-		for componentName, shutdownChan := range remainingComponents {
-			select {
-			case err := <-shutdownChan:
-				if err != nil {
-					componentErrors[componentName] = err
-				}
-			default:
-				futureRemComponents[componentName] = shutdownChan
-			}
-		}
+// endDrain disposes of the GracefulShutdown instance
+func (gs *GracefulShutdown) endDrain() {
+	gs.componentMutex.Lock()
+	defer gs.componentMutex.Unlock()
 
-		// All components were shutdown with success
-		if len(futureRemComponents) == 0 {
-			if len(componentErrors) == 0 {
-				return nil
-			}
+	gs.shuttingDown = false
+	gs.disposed = true
+}
 
-			return ShutdownError{
-				ComponentErrors: componentErrors,
-			}
-		}
+// SignalError is the shutdown reason reported when the shutdown was triggered by an OS Signal
+type SignalError struct {
+	Signal os.Signal
+}
 
-		remainingComponents = futureRemComponents
-		time.Sleep(gs.options.PollDuration)
-	}
+func (err SignalError) Error() string {
+	return fmt.Sprintf("received signal %s", err.Signal)
+}
+
+// PanicError is reported when a component panicked while shutting down. It wraps [ErrComponentPanicked].
+type PanicError struct {
+	// Value is the value the component panicked with
+	Value any
+	// Stack is the stack trace of the goroutine at the moment of the panic
+	Stack []byte
+}
+
+func (err PanicError) Error() string {
+	return fmt.Sprintf("%s: %v\n%s", ErrComponentPanicked, err.Value, err.Stack)
+}
+
+func (err PanicError) Unwrap() error {
+	return ErrComponentPanicked
 }
 
 // ShutdownError details errors by component
 type ShutdownError struct {
 	ComponentErrors map[string]error
+	// Groups summarizes the shutdown of each [ComponentGroup], by group name
+	Groups map[string]GroupSummary
+	// Report details how every component behaved during the shutdown
+	Report ShutdownReport
+	// GoroutineDump is the stack of all goroutines, captured when a component timed out and
+	// [GracefulShutdownOptions.DumpGoroutinesOnTimeout] is enabled
+	GoroutineDump []byte
 }
 
 func (err ShutdownError) Error() string {
 	return fmt.Sprintf("error while shutting down (%+v)", err.ComponentErrors)
 }
 
+// hasTimeout returns true if any component error is of type [ErrShutdownTimeout]
+func (err ShutdownError) hasTimeout() bool {
+	for _, componentErr := range err.ComponentErrors {
+		if errors.Is(componentErr, ErrShutdownTimeout) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// goroutineDump returns the stack of all goroutines
+func goroutineDump() []byte {
+	buf := make([]byte, 64*1024)
+
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
 // IsTimeout returns true if all component errors are of type [ErrShutdownTimeout]
 func (err ShutdownError) IsTimeoutErr() bool {
 	for _, error := range err.ComponentErrors {
@@ -3,10 +3,15 @@ package lifecycle_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
 	assert2 "github.com/stretchr/testify/assert"
 )
 
@@ -83,6 +88,91 @@ func Test_GracefulShutdown_TimeoutError(t *testing.T) {
 	assert.True(shutdownErr.IsTimeoutErr(), "ShutdownError should only return timeout errors")
 }
 
+func Test_GracefulShutdown_TimedOutComponent_ShouldNotLeakGoroutine(t *testing.T) {
+	assert := assert2.New(t)
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:                  20 * time.Millisecond,
+		DetectGoroutineLeaks:     true,
+		GoroutineLeakGracePeriod: time.Second,
+	})
+
+	// The component completes after the timeout, once it is no longer awaited
+	CreateSuccessComponent(gs, "ComponentA", 60*time.Millisecond)
+
+	report, err := gs.ShutdownWithReport()
+	assert.Error(err)
+
+	for _, leak := range report.Leaks {
+		assert.NotContains(leak.Stack, "registerComponentFn", "the component's goroutine should exit")
+	}
+}
+
+func Test_GracefulShutdown_ComponentNeverSignaled_ShouldNotLeakGoroutine(t *testing.T) {
+	assert := assert2.New(t)
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:                  20 * time.Millisecond,
+		DetectGoroutineLeaks:     true,
+		GoroutineLeakGracePeriod: time.Second,
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	assert.NoError(gs.RegisterComponentWithFn("ComponentA", func() error {
+		<-release
+		return nil
+	}))
+	// Waits for ComponentA, which does not complete before the timeout
+	assert.NoError(gs.RegisterComponentWithFn("ComponentB", func() error {
+		return nil
+	}, lifecycle.DependsOn("ComponentA")))
+
+	report, err := gs.ShutdownWithReport()
+	assert.Error(err)
+
+	for _, leak := range report.Leaks {
+		// ComponentA is still shutting down, while ComponentB's goroutine would be waiting for its turn
+		if !strings.Contains(leak.Stack, "callShutdownFn") {
+			assert.NotContains(leak.Stack, "registerComponentFn", "the goroutine of the component never signaled should exit")
+		}
+	}
+}
+
+func Test_GracefulShutdown_NoComponents(t *testing.T) {
+	assert := assert2.New(t)
+
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	assert.NoError(gs.Shutdown(), "shutting down without components should succeed by default")
+
+	warned := false
+	gs = lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		ErrorOnNoComponents: true,
+		OnNoComponents: func() {
+			warned = true
+		},
+	})
+
+	assert.ErrorIs(gs.Shutdown(), lifecycle.ErrNoComponents)
+	assert.True(warned)
+	assert.Error(gs.AppContext().Err(), "the AppContext should be cancelled")
+}
+
+func Test_GracefulShutdown_ErrorOnNoComponents_WhenComponentsRegistered_ShouldSucceed(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		ErrorOnNoComponents: true,
+		OnNoComponents: func() {
+			assert.Fail("OnNoComponents should not be invoked")
+		},
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+
+	assert.NoError(gs.Shutdown())
+}
+
 func Test_GracefulShutdown_ErrorRegisterComponentTwice(t *testing.T) {
 	assert := assert2.New(t)
 	gs := lifecycle.NewGracefulShutdown(context.Background())
@@ -98,3 +188,791 @@ func Test_GracefulShutdown_ErrorRegisterComponentTwice(t *testing.T) {
 
 	assert.ErrorIs(err, lifecycle.ErrComponentAlreadyRegistered)
 }
+
+func Test_GracefulShutdown_PhasesAreDrainedInDescendingOrder(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	mutex := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string, delay time.Duration) func() error {
+		return func() error {
+			time.Sleep(delay)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, name)
+
+			return nil
+		}
+	}
+
+	assert.NoError(gs.RegisterComponentInPhase("db-pool", 0, record("db-pool", 0)))
+	assert.NoError(gs.RegisterComponentInPhase("http-server", 10, record("http-server", 200*time.Millisecond)))
+	assert.NoError(gs.RegisterComponentInPhase("telemetry", -10, record("telemetry", 0)))
+
+	err := gs.Shutdown()
+	assert.NoError(err)
+
+	assert.Equal([]string{"http-server", "db-pool", "telemetry"}, order)
+}
+
+func Test_GracefulShutdown_TimeoutInPhase_ShouldTimeoutLowerPhases(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:      150 * time.Millisecond,
+		PollDuration: 25 * time.Millisecond,
+	})
+
+	called := atomic.Bool{}
+
+	_ = gs.RegisterComponentInPhase("slow", 1, func() error {
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	})
+	_ = gs.RegisterComponentInPhase("next", 0, func() error {
+		called.Store(true)
+		return nil
+	})
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr, "error should be a ShutdownError") {
+		return
+	}
+
+	assert.True(shutdownErr.IsTimeoutErr())
+	assert.Contains(shutdownErr.ComponentErrors, "slow")
+	assert.Contains(shutdownErr.ComponentErrors, "next")
+	assert.False(called.Load(), "next phase should never have been signaled")
+}
+
+type stopperFunc func(ctx context.Context) error
+
+func (fn stopperFunc) Stop(ctx context.Context) error {
+	return fn(ctx)
+}
+
+func Test_GracefulShutdown_PhaseTimeouts_ShouldReserveTimeForLowerPhases(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: time.Second,
+		PhaseTimeouts: map[int]time.Duration{
+			1: 100 * time.Millisecond,
+		},
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	slowBudget := make(chan time.Duration, 1)
+	assert.NoError(gs.RegisterService("slow", stopperFunc(func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		slowBudget <- time.Until(deadline)
+
+		<-release
+		return nil
+	}), lifecycle.InPhase(1)))
+
+	var nextBudget time.Duration
+	assert.NoError(gs.RegisterService("next", stopperFunc(func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		nextBudget = time.Until(deadline)
+		return nil
+	})))
+
+	report, err := gs.ShutdownWithReport()
+	assert.Error(err)
+
+	assert.Equal([]string{"slow"}, report.TimedOut())
+	assert.Equal([]string{"next"}, report.Succeeded())
+	assert.LessOrEqual(<-slowBudget, 100*time.Millisecond)
+	assert.Greater(nextBudget, 500*time.Millisecond, "next phase should receive the remaining time")
+}
+
+func Test_GracefulShutdown_MaxConcurrentShutdowns_ShouldLimitFanOut(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		MaxConcurrentShutdowns: 3,
+	})
+
+	active := atomic.Int32{}
+	maxActive := atomic.Int32{}
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(gs.RegisterComponentWithFn(fmt.Sprintf("component-%d", i), func() error {
+			current := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				previous := maxActive.Load()
+				if current <= previous || maxActive.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}))
+	}
+
+	report, err := gs.ShutdownWithReport()
+	assert.NoError(err)
+
+	assert.Len(report.Succeeded(), 10)
+	assert.Equal(int32(3), maxActive.Load())
+}
+
+func Test_GracefulShutdown_MaxConcurrentShutdowns_ShouldSignalInRegistrationOrder(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		MaxConcurrentShutdowns: 1,
+	})
+
+	names := make([]string, 0)
+	expected := make([]string, 0)
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("component-%d", i)
+		expected = append(expected, name)
+
+		assert.NoError(gs.RegisterComponentWithFn(name, func() error {
+			names = append(names, name)
+			return nil
+		}))
+	}
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal(expected, names)
+}
+
+func Test_GracefulShutdown_ReverseRegistrationOrder_ShouldShutdownInLIFOOrder(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		ReverseRegistrationOrder: true,
+	})
+
+	mutex := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string) func() error {
+		return func() error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, name)
+
+			return nil
+		}
+	}
+
+	assert.NoError(gs.RegisterComponentWithFn("config", record("config")))
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", record("db-pool")))
+	assert.NoError(gs.RegisterComponentWithFn("repository", record("repository")))
+	assert.NoError(gs.RegisterComponentInPhase("telemetry", -10, record("telemetry")))
+	assert.NoError(gs.RegisterComponentWithFn("http-server", record("http-server")))
+
+	assert.NoError(gs.Shutdown())
+
+	assert.Equal([]string{"http-server", "repository", "db-pool", "config", "telemetry"}, order)
+}
+
+func Test_GracefulShutdown_ReverseRegistrationOrder_WhenDependingOnEarlierComponent_ShouldReportCycle(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		ReverseRegistrationOrder: true,
+	})
+
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", func() error { return nil }))
+
+	err := gs.RegisterComponentWithFn("repository", func() error { return nil }, lifecycle.DependsOn("db-pool"))
+	assert.ErrorIs(err, lifecycle.ErrDependencyCycle)
+}
+
+func Test_GracefulShutdown_After_ShouldDrainAfterComponent(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	httpServerDone := atomic.Bool{}
+	assert.NoError(gs.RegisterComponentWithFn("http-server", func() error {
+		time.Sleep(50 * time.Millisecond)
+		httpServerDone.Store(true)
+		return nil
+	}))
+
+	drainedAfter := false
+	assert.NoError(gs.RegisterComponentWithFn("event-publisher", func() error {
+		drainedAfter = httpServerDone.Load()
+		return nil
+	}, lifecycle.After("http-server")))
+
+	assert.NoError(gs.Shutdown())
+	assert.True(drainedAfter, "event-publisher should drain after the http-server")
+}
+
+func Test_GracefulShutdown_DependenciesAreDrainedInTopologicalOrder(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	mutex := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string, delay time.Duration) func() error {
+		return func() error {
+			time.Sleep(delay)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			order = append(order, name)
+
+			return nil
+		}
+	}
+
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", record("db-pool", 0), lifecycle.DependsOn("http-server", "worker")))
+	assert.NoError(gs.RegisterComponentWithFn("http-server", record("http-server", 150*time.Millisecond)))
+	assert.NoError(gs.RegisterComponentWithFn("worker", record("worker", 0), lifecycle.DependsOn("http-server")))
+
+	err := gs.Shutdown()
+	assert.NoError(err)
+
+	assert.Equal([]string{"http-server", "worker", "db-pool"}, order)
+}
+
+func Test_GracefulShutdown_ErrorDependencyCycle(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	_, err := gs.RegisterComponent("a", lifecycle.DependsOn("c"))
+	assert.NoError(err)
+	_, err = gs.RegisterComponent("b", lifecycle.DependsOn("a"))
+	assert.NoError(err)
+
+	_, err = gs.RegisterComponent("c", lifecycle.DependsOn("b"))
+	assert.ErrorIs(err, lifecycle.ErrDependencyCycle)
+	assert.NotContains(gs.RegisteredComponents(), "c", "component should not be registered")
+
+	// Depending on a component of a later phase can never be satisfied
+	err = gs.RegisterComponentInPhase("d", 10, func() error { return nil }, lifecycle.DependsOn("a"))
+	assert.ErrorIs(err, lifecycle.ErrDependencyCycle)
+}
+
+func Test_GracefulShutdown_UnregisteredComponentIsNotAwaited(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 250 * time.Millisecond,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+
+	// This component will never report
+	_, err := gs.RegisterComponent("worker")
+	assert.NoError(err)
+
+	assert.NoError(gs.UnregisterComponent("worker"))
+	assert.ElementsMatch([]string{"ComponentA"}, gs.RegisteredComponents())
+
+	err = gs.Shutdown()
+	assert.NoError(err)
+}
+
+func Test_GracefulShutdown_ErrorUnregisterUnknownComponent(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	err := gs.UnregisterComponent("unknown")
+	assert.ErrorIs(err, lifecycle.ErrComponentNotRegistered)
+}
+
+func Test_GracefulShutdown_ReturnsAsSoonAsLastComponentReports(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		PollDuration: 2 * time.Second,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 10*time.Millisecond)
+	CreateSuccessComponent(gs, "ComponentB", 0)
+
+	start := time.Now()
+	err := gs.Shutdown()
+	assert.NoError(err)
+
+	assert.Less(time.Since(start), 500*time.Millisecond, "shutdown should not wait for a poll cycle")
+}
+
+func Test_GracefulShutdown_ShutdownWithContext_DeadlineReplacesTimeout(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 5 * time.Second,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+	CreateSuccessComponent(gs, "ComponentB", time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := gs.ShutdownWithContext(ctx)
+	assert.Less(time.Since(start), 500*time.Millisecond, "shutdown should be capped by the context deadline")
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr, "error should be a ShutdownError") {
+		return
+	}
+
+	assert.True(shutdownErr.IsTimeoutErr())
+	assert.Equal(lifecycle.ErrShutdownTimeout, shutdownErr.ComponentErrors["ComponentB"])
+	assert.NotContains(shutdownErr.ComponentErrors, "ComponentA")
+}
+
+func Test_GracefulShutdown_ShutdownReason(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.NoError(gs.ShutdownReason(), "no reason before shutdown")
+
+	_ = gs.Shutdown()
+
+	assert.ErrorIs(gs.ShutdownReason(), lifecycle.ErrShutdownRequested)
+	assert.ErrorIs(context.Cause(gs.AppContext()), lifecycle.ErrShutdownRequested)
+}
+
+func Test_GracefulShutdown_PreShutdownDelay(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		PreShutdownDelay: 150 * time.Millisecond,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+
+	done := make(chan error)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	select {
+	case <-gs.Draining():
+	case <-time.After(50 * time.Millisecond):
+		assert.Fail("should be draining as soon as the shutdown is requested")
+	}
+
+	assert.NoError(gs.AppContext().Err(), "AppContext should not be cancelled during the pre-shutdown delay")
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Error(gs.AppContext().Err(), "AppContext should be cancelled after the pre-shutdown delay")
+	assert.NoError(<-done)
+}
+
+func Test_GracefulShutdown_ConcurrentShutdown_ShouldWaitForFirstShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	clock := lifecycletest.NewClock(time.Now())
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Clock:            clock,
+		PreShutdownDelay: time.Second,
+	})
+
+	CreateErrorComponent(gs, "ComponentA", errors.New("test error"))
+
+	first := make(chan error, 1)
+	go func() {
+		first <- gs.Shutdown()
+	}()
+	<-gs.Draining()
+
+	second := make(chan error, 1)
+	go func() {
+		second <- gs.Shutdown()
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(gs.AppContext().Err(), "the second shutdown should not skip the pre-shutdown delay")
+	assert.Empty(second, "the second shutdown should wait for the first one")
+
+	<-clock.TimersCreated(1)
+	clock.Advance(time.Second)
+
+	firstErr := <-first
+	assert.Error(firstErr)
+	assert.Equal(firstErr, <-second, "the second shutdown should return the result of the first one")
+
+	assert.ErrorIs(gs.Shutdown(), lifecycle.ErrAlreadyShutdown)
+}
+
+func Test_GracefulShutdown_Hooks(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("test error")
+	CreateErrorComponent(gs, "ComponentA", expectedErr)
+
+	events := make([]string, 0)
+	var completeErr error
+
+	gs.OnShutdownStart(func() {
+		assert.NoError(gs.AppContext().Err(), "components should not be signaled yet")
+		events = append(events, "start")
+	})
+	gs.OnShutdownComplete(func(err error) {
+		events = append(events, "complete")
+		completeErr = err
+	})
+
+	err := gs.Shutdown()
+	assert.Error(err)
+
+	// Hooks only run for the first shutdown
+	assert.ErrorIs(gs.Shutdown(), lifecycle.ErrAlreadyShutdown)
+
+	assert.Equal([]string{"start", "complete"}, events)
+	assert.Equal(err, completeErr)
+}
+
+func Test_GracefulShutdown_OnShutdownTimeout_ShouldReceivePendingComponents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+	CreateSuccessComponent(gs, "ComponentC", time.Second)
+	_ = gs.RegisterComponentWithFn("ComponentB", func() error {
+		time.Sleep(time.Second)
+		return nil
+	}, lifecycle.DependsOn("ComponentC"))
+
+	var pending []string
+	completed := false
+	gs.OnShutdownTimeout(func(names []string) {
+		assert.False(completed, "timeout hooks should run before the complete hooks")
+		pending = names
+	})
+	gs.OnShutdownComplete(func(err error) {
+		completed = true
+	})
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr, "error should be a ShutdownError") {
+		return
+	}
+
+	assert.True(shutdownErr.IsTimeoutErr())
+	assert.Equal([]string{"ComponentB", "ComponentC"}, pending)
+}
+
+func Test_GracefulShutdown_OnShutdownTimeout_ShouldNotRunWithoutTimeout(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+
+	called := false
+	gs.OnShutdownTimeout(func(names []string) {
+		called = true
+	})
+
+	assert.NoError(gs.Shutdown())
+	assert.False(called)
+}
+
+func Test_GracefulShutdown_PanickingComponent_ShouldReportError(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: time.Second,
+	})
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+	_ = gs.RegisterComponentWithFn("ComponentB", func() error {
+		panic("boom")
+	})
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr, "error should be a ShutdownError") {
+		return
+	}
+
+	assert.False(shutdownErr.IsTimeoutErr(), "panic should be reported before the timeout")
+
+	componentErr := shutdownErr.ComponentErrors["ComponentB"]
+	assert.ErrorIs(componentErr, lifecycle.ErrComponentPanicked)
+
+	panicErr := lifecycle.PanicError{}
+	if assert.ErrorAs(componentErr, &panicErr) {
+		assert.Equal("boom", panicErr.Value)
+		assert.NotEmpty(panicErr.Stack)
+	}
+}
+
+type testCloser struct {
+	closed atomic.Bool
+	err    error
+}
+
+func (c *testCloser) Close() error {
+	c.closed.Store(true)
+	return c.err
+}
+
+func Test_GracefulShutdown_RegisterCloser(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("test error")
+	closerA := &testCloser{}
+	closerB := &testCloser{err: expectedErr}
+
+	assert.NoError(gs.RegisterCloser("ComponentA", closerA))
+	assert.NoError(gs.RegisterCloser("ComponentB", closerB))
+	assert.False(closerA.closed.Load(), "closer should not be closed before shutdown")
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr, "error should be a ShutdownError") {
+		assert.ErrorIs(shutdownErr.ComponentErrors["ComponentB"], expectedErr)
+		assert.NotContains(shutdownErr.ComponentErrors, "ComponentA")
+	}
+
+	assert.True(closerA.closed.Load())
+	assert.True(closerB.closed.Load())
+}
+
+func Test_GracefulShutdown_RegisterChild_ShouldCascadeShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	parent := lifecycle.NewGracefulShutdown(context.Background())
+	child := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("test error")
+	CreateSuccessComponent(child, "worker-a", 50*time.Millisecond)
+	CreateErrorComponent(child, "worker-b", expectedErr)
+	CreateSuccessComponent(parent, "http-server", 0)
+
+	assert.NoError(parent.RegisterChild("worker-subsystem", child))
+
+	err := parent.Shutdown()
+
+	assert.Error(child.AppContext().Err(), "child should have been shutdown")
+	assert.ErrorIs(child.ShutdownReason(), lifecycle.ErrShutdownRequested)
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr) {
+		return
+	}
+
+	childErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(shutdownErr.ComponentErrors["worker-subsystem"], &childErr, "child error should be a ShutdownError") {
+		assert.ErrorIs(childErr.ComponentErrors["worker-b"], expectedErr)
+	}
+}
+
+func Test_GracefulShutdown_Reset_ShouldAllowAnotherShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.ErrorIs(gs.Reset(), lifecycle.ErrShutdownNotCompleted)
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+	assert.NoError(gs.Shutdown())
+	assert.Error(gs.AppContext().Err())
+
+	assert.NoError(gs.Reset())
+	assert.NoError(gs.AppContext().Err(), "AppContext should be renewed")
+	assert.Empty(gs.RegisteredComponents(), "components should be unregistered")
+
+	expectedErr := errors.New("test error")
+	CreateErrorComponent(gs, "ComponentA", expectedErr)
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.ErrorIs(shutdownErr.ComponentErrors["ComponentA"], expectedErr)
+	}
+}
+
+func Test_GracefulShutdown_ExitCode(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		FailureExitCode: 3,
+		TimeoutExitCode: 4,
+	})
+
+	assert.Equal(0, gs.ExitCode(nil))
+	assert.Equal(3, gs.ExitCode(errors.New("test error")))
+	assert.Equal(3, gs.ExitCode(lifecycle.ShutdownError{
+		ComponentErrors: map[string]error{
+			"ComponentA": errors.New("test error"),
+			"ComponentB": lifecycle.ErrShutdownTimeout,
+		},
+	}))
+	assert.Equal(4, gs.ExitCode(lifecycle.ShutdownError{
+		ComponentErrors: map[string]error{
+			"ComponentB": lifecycle.ErrShutdownTimeout,
+		},
+	}))
+}
+
+func Test_GracefulShutdown_TimeoutWithGoroutineDump(t *testing.T) {
+	assert := assert2.New(t)
+
+	var dumped []byte
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:                 100 * time.Millisecond,
+		DumpGoroutinesOnTimeout: true,
+		OnGoroutineDump: func(dump []byte) {
+			dumped = dump
+		},
+	})
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	_ = gs.RegisterComponentWithFn("stuck", func() error {
+		<-blocked
+		return nil
+	})
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr) {
+		return
+	}
+
+	assert.Contains(string(shutdownErr.GoroutineDump), "Test_GracefulShutdown_TimeoutWithGoroutineDump", "dump should show where the component is stuck")
+	assert.Equal(shutdownErr.GoroutineDump, dumped)
+}
+
+func Test_GracefulShutdown_ErrorRegisterComponentAfterShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	CreateSuccessComponent(gs, "ComponentA", 0)
+	assert.NoError(gs.Shutdown())
+
+	_, err := gs.RegisterComponent("late")
+	assert.ErrorIs(err, lifecycle.ErrAlreadyShutdown)
+
+	err = gs.RegisterComponentWithFn("late-fn", func() error {
+		return nil
+	})
+	assert.ErrorIs(err, lifecycle.ErrAlreadyShutdown)
+
+	assert.ElementsMatch([]string{"ComponentA"}, gs.RegisteredComponents())
+}
+
+func Test_GracefulShutdown_RegisterComponentDuringShutdown_ShouldFail(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	CreateSuccessComponent(gs, "ComponentA", 100*time.Millisecond)
+
+	done := make(chan error)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	time.Sleep(25 * time.Millisecond)
+
+	_, err := gs.RegisterComponent("late")
+	assert.ErrorIs(err, lifecycle.ErrAlreadyShutdown)
+	assert.NoError(<-done)
+}
+
+func Test_GracefulShutdown_ReadAPIsRemainResponsiveDuringShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	_ = gs.RegisterComponentWithFn("ComponentA", func() error {
+		time.Sleep(500 * time.Millisecond)
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	time.Sleep(25 * time.Millisecond)
+
+	start := time.Now()
+	assert.Equal([]string{"ComponentA"}, gs.RegisteredComponents())
+	assert.Less(time.Since(start), 100*time.Millisecond, "RegisteredComponents should not wait for the shutdown")
+
+	assert.NoError(<-done)
+}
+
+func Test_GracefulShutdown_WhenReadyCheckIsAttached_ShouldDrainBeforeComponents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPushComponent("component-1").SetReady(true)
+	gs.AttachReadyCheck(rdy)
+
+	assert.True(rdy.Ready())
+
+	readyDuringShutdown := true
+	_ = gs.RegisterComponentWithFn("component-1", func() error {
+		readyDuringShutdown = rdy.Ready()
+		return nil
+	})
+
+	assert.NoError(gs.Shutdown())
+	assert.False(readyDuringShutdown, "ready check should be draining before components shut down")
+
+	assert.NoError(gs.Reset())
+	assert.True(rdy.Ready(), "ready check should no longer be draining after reset")
+}
+
+func Test_GracefulShutdown_RegisterFinalizer_ShouldRunAfterComponentsEvenOnTimeout(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 50 * time.Millisecond,
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	mutex := sync.Mutex{}
+	order := make([]string, 0)
+	record := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		order = append(order, name)
+	}
+
+	assert.NoError(gs.RegisterComponentWithFn("hung", func() error {
+		<-release
+		return nil
+	}))
+	gs.OnShutdownComplete(func(err error) {
+		record("complete-hook")
+	})
+
+	flushErr := errors.New("exporter unreachable")
+	gs.RegisterFinalizer(func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(hasDeadline)
+		assert.NoError(ctx.Err(), "finalizer should get its own time budget")
+
+		record("flush-traces")
+		return flushErr
+	})
+	gs.RegisterFinalizer(func(ctx context.Context) error {
+		record("flush-logs")
+		return nil
+	})
+
+	err := gs.Shutdown()
+
+	assert.Equal([]string{"complete-hook", "flush-traces", "flush-logs"}, order)
+	assert.ErrorIs(err, lifecycle.ErrFinalizerFailed)
+	assert.ErrorIs(err, flushErr)
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.True(shutdownErr.IsTimeoutErr())
+	}
+}
@@ -0,0 +1,102 @@
+//go:build unix
+
+package lifecycle_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_GracefulShutdown_SecondSignal_ShouldForceExit(t *testing.T) {
+	assert := assert2.New(t)
+
+	exitCode := make(chan int, 1)
+	restore := lifecycle.SetExitFunc(func(code int) {
+		exitCode <- code
+	})
+	defer restore()
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:                 time.Second,
+		Signals:                 []os.Signal{syscall.SIGUSR1},
+		ForceExitOnSecondSignal: true,
+		ForceExitCode:           42,
+	})
+
+	// This component hangs for the whole timeout
+	CreateSuccessComponent(gs, "hung", 2*time.Second)
+
+	go func() {
+		_ = gs.WaitForShutdown()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	select {
+	case code := <-exitCode:
+		assert.Equal(42, code)
+	case <-time.After(500 * time.Millisecond):
+		assert.Fail("process should have been forced to exit")
+	}
+}
+
+func Test_GracefulShutdown_ShutdownReason_ShouldReportSignal(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Signals: []os.Signal{syscall.SIGUSR2},
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	assert.NoError(<-done)
+
+	signalErr := lifecycle.SignalError{}
+	if assert.ErrorAs(gs.ShutdownReason(), &signalErr) {
+		assert.Equal(syscall.SIGUSR2, signalErr.Signal)
+	}
+}
+
+func Test_GracefulShutdown_RunUntilShutdown_ShouldExitWithCode(t *testing.T) {
+	assert := assert2.New(t)
+
+	exitCode := make(chan int, 1)
+	restore := lifecycle.SetExitFunc(func(code int) {
+		exitCode <- code
+	})
+	defer restore()
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:         100 * time.Millisecond,
+		Signals:         []os.Signal{syscall.SIGUSR1},
+		TimeoutExitCode: 7,
+	})
+
+	CreateSuccessComponent(gs, "hung", time.Second)
+
+	go gs.RunUntilShutdown()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	select {
+	case code := <-exitCode:
+		assert.Equal(7, code)
+	case <-time.After(time.Second):
+		assert.Fail("process should have exited")
+	}
+}
@@ -0,0 +1,140 @@
+package lifecycle
+
+import (
+	"errors"
+	"time"
+)
+
+// GroupOptions are options used in conjunction with the [ComponentGroup] type
+type GroupOptions struct {
+	// Timeout is the time allocated to the group's components, measured from the moment the first component of the group is
+	// signaled. Components still shutting down after the timeout are considered non-responsive. The global shutdown timeout
+	// still applies.
+	//
+	// Default: no group timeout
+	Timeout time.Duration
+	// Phase is the shutdown phase of all the group's components
+	//
+	// Default: DefaultPhase
+	Phase int
+}
+
+// ComponentGroup is a named set of components which are drained as a unit, within the same phase and with a shared timeout
+type ComponentGroup struct {
+	gs *GracefulShutdown
+
+	name    string
+	options GroupOptions
+}
+
+// GroupSummary summarizes how the components of a [ComponentGroup] shutdown
+type GroupSummary struct {
+	// Components is the list of components which are part of the group
+	Components []string
+	// ComponentErrors are the errors reported by the group's components
+	ComponentErrors map[string]error
+}
+
+// TimedOut returns true if any of the group's components timed out
+func (summary GroupSummary) TimedOut() bool {
+	for _, err := range summary.ComponentErrors {
+		if errors.Is(err, ErrShutdownTimeout) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Group creates a [ComponentGroup] with the given options. If the group already exists, its options are replaced, and its
+// phase applies to the components already registered within it. Once the shutdown has occurred, or when the new phase would
+// make the dependencies of the group's components form a cycle, the options of an existing group are left unchanged. Use
+// [ComponentGroup.Reconfigure] to know whether the options were applied.
+func (gs *GracefulShutdown) Group(name string, options GroupOptions) *ComponentGroup {
+	gs.componentMutex.Lock()
+	defer gs.componentMutex.Unlock()
+
+	group, ok := gs.groups[name]
+	if !ok {
+		group = &ComponentGroup{
+			gs:      gs,
+			name:    name,
+			options: options,
+		}
+		gs.groups[name] = group
+
+		return group
+	}
+
+	_ = group.reconfigure(options)
+
+	return group
+}
+
+// Reconfigure replaces the options of the group, and applies its phase to the components already registered within it.
+//
+// When the new phase would make the dependencies of the group's components form a cycle, the options are left unchanged and
+// an [ErrDependencyCycle] error is returned. Once the shutdown has occurred, a [ErrAlreadyShutdown] error is returned.
+func (group *ComponentGroup) Reconfigure(options GroupOptions) error {
+	group.gs.componentMutex.Lock()
+	defer group.gs.componentMutex.Unlock()
+
+	return group.reconfigure(options)
+}
+
+// reconfigure replaces the options of the group, rolling back the phase of its components if they would form a cycle. The
+// component lock must be held.
+func (group *ComponentGroup) reconfigure(options GroupOptions) error {
+	gs := group.gs
+	if gs.shuttingDown || gs.disposed {
+		return ErrAlreadyShutdown
+	}
+
+	previous := group.options
+	members := make([]*component, 0)
+
+	for _, c := range gs.components {
+		if c.group == group.name {
+			c.phase = options.Phase
+			members = append(members, c)
+		}
+	}
+
+	reverse := gs.options.ReverseRegistrationOrder
+	for _, c := range members {
+		if dependsOn(gs.components, c, c.name, make(map[string]bool), reverse) {
+			for _, member := range members {
+				member.phase = previous.Phase
+			}
+
+			return ErrDependencyCycle
+		}
+	}
+
+	group.options = options
+
+	return nil
+}
+
+// InGroup adds the component to the given group. The group must have been created using [GracefulShutdown.Group] beforehand,
+// otherwise the registration returns a [ErrGroupNotFound] error. The group's phase replaces the component's phase.
+func InGroup(name string) ComponentOption {
+	return func(c *component) {
+		c.group = name
+	}
+}
+
+// Name is the name of the group
+func (group *ComponentGroup) Name() string {
+	return group.name
+}
+
+// RegisterComponent registers a component within the group. See [GracefulShutdown.RegisterComponent].
+func (group *ComponentGroup) RegisterComponent(name string, opts ...ComponentOption) (ShutdownChan, error) {
+	return group.gs.RegisterComponent(name, append(opts, InGroup(group.name))...)
+}
+
+// RegisterComponentWithFn registers a component within the group. See [GracefulShutdown.RegisterComponentWithFn].
+func (group *ComponentGroup) RegisterComponentWithFn(name string, shutdownFn func() error, opts ...ComponentOption) error {
+	return group.gs.RegisterComponentWithFn(name, shutdownFn, append(opts, InGroup(group.name))...)
+}
@@ -0,0 +1,123 @@
+package lifecycle_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenGroupTimesOut_ShouldContinueWithNextPhases(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 2 * time.Second,
+	})
+
+	traffic := gs.Group("traffic", lifecycle.GroupOptions{
+		Timeout: 100 * time.Millisecond,
+		Phase:   10,
+	})
+
+	assert.NoError(traffic.RegisterComponentWithFn("http-server", func() error {
+		return nil
+	}))
+	assert.NoError(traffic.RegisterComponentWithFn("websockets", func() error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	storageCalled := atomic.Bool{}
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", func() error {
+		storageCalled.Store(true)
+		return nil
+	}))
+
+	start := time.Now()
+	err := gs.Shutdown()
+	assert.Less(time.Since(start), 500*time.Millisecond, "group timeout should not hold back the shutdown")
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr) {
+		return
+	}
+
+	assert.True(storageCalled.Load(), "next phase should have been signaled after the group timed out")
+	assert.Equal(map[string]error{"websockets": lifecycle.ErrShutdownTimeout}, shutdownErr.ComponentErrors)
+
+	summary, ok := shutdownErr.Groups["traffic"]
+	if assert.True(ok, "traffic group should be summarized") {
+		assert.ElementsMatch([]string{"http-server", "websockets"}, summary.Components)
+		assert.True(summary.TimedOut())
+	}
+}
+
+func Test_WhenRegisteringInUnknownGroup_ShouldReturnError(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	_, err := gs.RegisterComponent("component", lifecycle.InGroup("unknown"))
+	assert.ErrorIs(err, lifecycle.ErrGroupNotFound)
+}
+
+func Test_WhenGroupIsReconfigured_ShouldMoveExistingComponentsToNewPhase(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	order := make(chan string, 2)
+
+	traffic := gs.Group("traffic", lifecycle.GroupOptions{})
+	assert.NoError(traffic.RegisterComponentWithFn("http-server", func() error {
+		order <- "http-server"
+		return nil
+	}))
+	assert.NoError(gs.RegisterComponentInPhase("db-pool", 5, func() error {
+		order <- "db-pool"
+		return nil
+	}))
+
+	gs.Group("traffic", lifecycle.GroupOptions{Phase: 10})
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal("http-server", <-order, "the group's new phase should be drained first")
+	assert.Equal("db-pool", <-order)
+}
+
+func Test_WhenGroupPhaseWouldFormCycle_ShouldRejectTheReconfiguration(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	order := make(chan string, 2)
+
+	assert.NoError(gs.RegisterComponentWithFn("db-pool", func() error {
+		order <- "db-pool"
+		return nil
+	}))
+	traffic := gs.Group("traffic", lifecycle.GroupOptions{})
+	assert.NoError(traffic.RegisterComponentWithFn("http-server", func() error {
+		order <- "http-server"
+		return nil
+	}, lifecycle.DependsOn("db-pool")))
+
+	assert.ErrorIs(traffic.Reconfigure(lifecycle.GroupOptions{Phase: 10}), lifecycle.ErrDependencyCycle)
+	gs.Group("traffic", lifecycle.GroupOptions{Phase: 10})
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal("db-pool", <-order, "the group should keep its phase")
+	assert.Equal("http-server", <-order)
+}
+
+func Test_WhenTimeoutIsWrapped_GroupShouldReportTimedOut(t *testing.T) {
+	assert := assert2.New(t)
+
+	summary := lifecycle.GroupSummary{
+		ComponentErrors: map[string]error{
+			"websockets": fmt.Errorf("draining connections: %w", lifecycle.ErrShutdownTimeout),
+		},
+	}
+
+	assert.True(summary.TimedOut())
+}
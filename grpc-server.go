@@ -0,0 +1,38 @@
+package lifecycle
+
+import "context"
+
+// GRPCStopper is the subset of the [*grpc.Server] methods used to shut it down. It allows registering a gRPC server without
+// depending on the gRPC module.
+//
+// [*grpc.Server]: https://pkg.go.dev/google.golang.org/grpc#Server
+type GRPCStopper interface {
+	// GracefulStop stops the server from accepting new connections and RPCs and blocks until all the pending RPCs are finished
+	GracefulStop()
+	// Stop closes all the connections and listeners, cancelling the pending RPCs
+	Stop()
+}
+
+// RegisterGRPCServer registers a gRPC server as a component. When signaled, the server is gracefully stopped. If the pending RPCs
+// do not complete within the remaining shutdown time, the server is forcefully stopped, and the component reports a
+// [ErrShutdownTimeout] error.
+func (gs *GracefulShutdown) RegisterGRPCServer(name string, srv GRPCStopper, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		stopped := make(chan struct{})
+
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+			<-stopped
+
+			return ErrShutdownTimeout
+		}
+	}, opts)
+}
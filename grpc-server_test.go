@@ -0,0 +1,66 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakeGRPCServer struct {
+	drainDuration time.Duration
+	stop          chan struct{}
+	stopCalled    atomic.Bool
+}
+
+func newFakeGRPCServer(drainDuration time.Duration) *fakeGRPCServer {
+	return &fakeGRPCServer{
+		drainDuration: drainDuration,
+		stop:          make(chan struct{}),
+	}
+}
+
+func (srv *fakeGRPCServer) GracefulStop() {
+	select {
+	case <-time.After(srv.drainDuration):
+	case <-srv.stop:
+	}
+}
+
+func (srv *fakeGRPCServer) Stop() {
+	srv.stopCalled.Store(true)
+	close(srv.stop)
+}
+
+func Test_WhenGRPCServerDrains_ShouldStopGracefully(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	srv := newFakeGRPCServer(50 * time.Millisecond)
+	assert.NoError(gs.RegisterGRPCServer("grpc-server", srv))
+
+	assert.NoError(gs.Shutdown())
+	assert.False(srv.stopCalled.Load(), "server should not have been forcefully stopped")
+}
+
+func Test_WhenGRPCServerDoesNotDrainInTime_ShouldStopForcefully(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	srv := newFakeGRPCServer(time.Minute)
+	assert.NoError(gs.RegisterGRPCServer("grpc-server", srv))
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.Equal(lifecycle.ErrShutdownTimeout, shutdownErr.ComponentErrors["grpc-server"])
+	}
+
+	assert.Eventually(srv.stopCalled.Load, time.Second, 10*time.Millisecond, "server should have been forcefully stopped")
+}
@@ -0,0 +1,139 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HealthJSONContentType is the media type of the [HealthDocument]
+const HealthJSONContentType = "application/health+json"
+
+// Statuses of the health+json format
+const (
+	HealthStatusPass = "pass"
+	HealthStatusWarn = "warn"
+	HealthStatusFail = "fail"
+)
+
+// Component types of the health+json format
+const (
+	HealthComponentTypeComponent = "component"
+	HealthComponentTypeDatastore = "datastore"
+	HealthComponentTypeSystem    = "system"
+)
+
+// HealthDocument is a health check response in the "health+json" format, described by the IETF draft "Health Check Response
+// Format for HTTP APIs"
+type HealthDocument struct {
+	// Status is the overall health: pass, warn or fail
+	Status string `json:"status"`
+	// Checks maps each component's name to its check
+	Checks map[string][]HealthCheckDetails `json:"checks,omitempty"`
+}
+
+// HealthCheckDetails is the outcome of a component's check in a [HealthDocument]
+type HealthCheckDetails struct {
+	// ComponentType is the type of the component
+	ComponentType string `json:"componentType,omitempty"`
+	// ObservedValue are the details reported by the check, if any
+	ObservedValue map[string]string `json:"observedValue,omitempty"`
+	// Status is the health of the component: pass, warn or fail
+	Status string `json:"status"`
+	// Time is the time at which the check was performed, in RFC 3339 format
+	Time string `json:"time,omitempty"`
+	// Output explains why the component is not healthy
+	Output string `json:"output,omitempty"`
+}
+
+// HealthDocument returns the health of the components in the "health+json" format. Each component is checked once.
+func (rdy *ReadyCheck) HealthDocument() HealthDocument {
+	components := rdy.snapshotComponents()
+	evaluations := rdy.evaluateComponents(components)
+	readiness, statuses := outcomes(evaluations)
+
+	status := rdy.worstStatus(components, statuses)
+	if !rdy.reportReadiness(nil, components, readiness) {
+		status = StatusNotReady
+	} else if status == StatusNotReady {
+		// A failure suppressed by the hold-down is reported as degraded, not to contradict the readiness
+		status = StatusDegraded
+	}
+
+	document := HealthDocument{
+		Status: healthStatus(status),
+		Checks: make(map[string][]HealthCheckDetails, len(components)),
+	}
+
+	for i, component := range components {
+		result := evaluations[i].result
+
+		details := HealthCheckDetails{
+			ComponentType: rdy.componentType(component),
+			ObservedValue: result.Details,
+			Status:        healthStatus(statuses[i]),
+		}
+		if !result.CheckedAt.IsZero() {
+			details.Time = result.CheckedAt.Format(time.RFC3339Nano)
+		}
+		if result.Err != nil {
+			details.Output = result.Err.Error()
+		}
+
+		document.Checks[component.Name()] = append(document.Checks[component.Name()], details)
+	}
+
+	return document
+}
+
+// HealthHandler returns an [http.Handler] serving the [HealthDocument]. It responds with a 200 OK status when the status is
+// pass or warn, and a 503 Service Unavailable status otherwise.
+func (rdy *ReadyCheck) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document := rdy.HealthDocument()
+
+		status := http.StatusOK
+		if document.Status == HealthStatusFail {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", HealthJSONContentType)
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(document)
+	})
+}
+
+// componentType returns the type set when registering the component, or the type derived from the kind of check. The pingers
+// are datastores and the checks of the process' resources are systems, while the other checks are reported as components.
+func (rdy *ReadyCheck) componentType(component ComponentCheck) string {
+	rdy.componentsMutex.RLock()
+	componentType := rdy.settings[component.Name()].componentType
+	rdy.componentsMutex.RUnlock()
+
+	if componentType != "" {
+		return componentType
+	}
+
+	switch check := component.(type) {
+	case *DiskSpaceComponentCheck, *RuntimeComponentCheck:
+		return HealthComponentTypeSystem
+	case *PollComponentCheck:
+		if check.componentType != "" {
+			return check.componentType
+		}
+	}
+
+	return HealthComponentTypeComponent
+}
+
+func healthStatus(status Status) string {
+	switch status {
+	case StatusReady:
+		return HealthStatusPass
+	case StatusDegraded:
+		return HealthStatusWarn
+	default:
+		return HealthStatusFail
+	}
+}
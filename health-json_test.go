@@ -0,0 +1,106 @@
+package lifecycle_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenComponentsAreChecked_ShouldProduceHealthDocument(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("db").SetReady(true)
+	readycheck.RegisterPushComponent("cache").SetStatus(lifecycle.StatusDegraded)
+
+	document := readycheck.HealthDocument()
+
+	assert.Equal(lifecycle.HealthStatusWarn, document.Status)
+	assert.Len(document.Checks, 2)
+	if assert.Len(document.Checks["db"], 1) {
+		assert.Equal(lifecycle.HealthStatusPass, document.Checks["db"][0].Status)
+		assert.Equal("component", document.Checks["db"][0].ComponentType)
+		assert.NotEmpty(document.Checks["db"][0].Time)
+	}
+	if assert.Len(document.Checks["cache"], 1) {
+		assert.Equal(lifecycle.HealthStatusWarn, document.Checks["cache"][0].Status)
+	}
+}
+
+func Test_WhenComponentFails_ShouldServeFailingHealthDocument(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("db").SetError(errors.New("connection refused"))
+
+	recorder := httptest.NewRecorder()
+	readycheck.HealthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(lifecycle.HealthJSONContentType, recorder.Header().Get("Content-Type"))
+
+	document := lifecycle.HealthDocument{}
+	assert.NoError(json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(lifecycle.HealthStatusFail, document.Status)
+	if assert.Len(document.Checks["db"], 1) {
+		assert.Equal(lifecycle.HealthStatusFail, document.Checks["db"][0].Status)
+		assert.Equal("connection refused", document.Checks["db"][0].Output)
+	}
+}
+
+func Test_WhenCheckResultIsMarshalled_ShouldEncodeError(t *testing.T) {
+	assert := assert2.New(t)
+
+	checkedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	encoded, err := json.Marshal(lifecycle.CheckResult{
+		Err:       errors.New("connection refused"),
+		Details:   map[string]string{"attempts": "3"},
+		CheckedAt: checkedAt,
+	})
+
+	assert.NoError(err)
+	assert.JSONEq(`{"ready":false,"error":"connection refused","details":{"attempts":"3"},"checkedAt":"2024-01-02T03:04:05Z"}`, string(encoded))
+
+	encoded, err = json.Marshal(lifecycle.CheckResult{Ready: true})
+	assert.NoError(err)
+	assert.JSONEq(`{"ready":true}`, string(encoded))
+}
+
+func Test_WhenHealthDocumentIsProduced_ShouldCheckEachComponentOnce(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("remote", countingComponentCheck{name: "remote", calls: calls})
+
+	document := readycheck.HealthDocument()
+	assert.Equal(lifecycle.HealthStatusPass, document.Status)
+	assert.Equal(lifecycle.HealthStatusPass, document.Checks["remote"][0].Status)
+	assert.Equal(int32(1), calls.Load(), "component should be checked once")
+}
+
+func Test_WhenComponentsAreOfDifferentKinds_ShouldReportTheirComponentType(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("db", lifecycle.PingCheckWithOptions("db", fakePinger{}, lifecycle.PingOptions{
+		Poll: lifecycle.PollOptions{ImmediateCheck: true},
+	}))
+	readycheck.RegisterComponent("runtime", lifecycle.RuntimeCheck("runtime", lifecycle.RuntimeOptions{}))
+	readycheck.RegisterPushComponent("queue", lifecycle.WithComponentType(lifecycle.HealthComponentTypeDatastore)).SetReady(true)
+	readycheck.RegisterPushComponent("cache").SetReady(true)
+
+	document := readycheck.HealthDocument()
+
+	assert.Equal(lifecycle.HealthComponentTypeDatastore, document.Checks["db"][0].ComponentType)
+	assert.Equal(lifecycle.HealthComponentTypeSystem, document.Checks["runtime"][0].ComponentType)
+	assert.Equal(lifecycle.HealthComponentTypeDatastore, document.Checks["queue"][0].ComponentType)
+	assert.Equal(lifecycle.HealthComponentTypeComponent, document.Checks["cache"][0].ComponentType)
+}
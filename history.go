@@ -0,0 +1,158 @@
+package lifecycle
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var DefaultHistorySize = 10
+
+// HistoryEntry is the outcome of a component's check, recorded in its history
+type HistoryEntry struct {
+	// At is the time at which the check was performed
+	At time.Time
+	// Status is the outcome of the check
+	Status Status
+	// Err explains why the component was not ready, if known
+	Err error
+	// Latency is the time taken by the check. It is zero for the checks which only record a pushed state.
+	Latency time.Duration
+}
+
+// VerboseResult is the outcome of a component's check, along with its history
+type VerboseResult struct {
+	// Result is the outcome of the check
+	Result CheckResult
+	// History are the most recent outcomes of the check, oldest first
+	History []HistoryEntry
+}
+
+// checkHistory is a bounded ring buffer of the most recent outcomes of a component's check
+type checkHistory struct {
+	mutex   *sync.Mutex
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+func newCheckHistory(size int) *checkHistory {
+	return &checkHistory{
+		mutex:   &sync.Mutex{},
+		entries: make([]HistoryEntry, size),
+	}
+}
+
+func (history *checkHistory) record(entry HistoryEntry) {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	history.entries[history.next] = entry
+	history.next = (history.next + 1) % len(history.entries)
+	if history.next == 0 {
+		history.full = true
+	}
+}
+
+// snapshot returns the recorded entries, oldest first
+func (history *checkHistory) snapshot() []HistoryEntry {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	if !history.full {
+		return append([]HistoryEntry{}, history.entries[:history.next]...)
+	}
+
+	return append(append([]HistoryEntry{}, history.entries[history.next:]...), history.entries[:history.next]...)
+}
+
+// historyRecorder is embedded by the built-in checks to record their outcomes in the history kept by the ReadyCheck they are
+// registered in
+type historyRecorder struct {
+	history atomic.Pointer[checkHistory]
+}
+
+func (recorder *historyRecorder) setHistory(history *checkHistory) {
+	recorder.history.Store(history)
+}
+
+func (recorder *historyRecorder) recordHistory(status Status, err error, latency time.Duration) {
+	if history := recorder.history.Load(); history != nil {
+		history.record(HistoryEntry{At: time.Now(), Status: status, Err: err, Latency: latency})
+	}
+}
+
+// ComponentHistory returns the most recent outcomes of the checks of the component registered with the given name, oldest
+// first. The number of outcomes kept is set by [ReadyCheckOptions.HistorySize].
+func (rdy *ReadyCheck) ComponentHistory(name string) []HistoryEntry {
+	rdy.componentsMutex.RLock()
+	history, ok := rdy.histories[name]
+	rdy.componentsMutex.RUnlock()
+
+	if !ok {
+		return []HistoryEntry{}
+	}
+
+	return history.snapshot()
+}
+
+// ExplainVerbose returns a map detailling the outcome of each component's check, along with its most recent outcomes. See
+// [ReadyCheck.ExplainDetailed].
+func (rdy *ReadyCheck) ExplainVerbose() map[string]VerboseResult {
+	results := rdy.ExplainDetailed()
+
+	explanation := make(map[string]VerboseResult, len(results))
+	for name, result := range results {
+		explanation[name] = VerboseResult{
+			Result:  result,
+			History: rdy.ComponentHistory(name),
+		}
+	}
+
+	return explanation
+}
+
+// trackHistory creates the history of a component being registered. Must be called while holding the components lock.
+func (rdy *ReadyCheck) trackHistory(component ComponentCheck) {
+	if rdy.options.HistorySize <= 0 {
+		return
+	}
+
+	history := newCheckHistory(rdy.options.HistorySize)
+	rdy.histories[component.Name()] = history
+
+	if recorder, ok := component.(interface{ setHistory(history *checkHistory) }); ok {
+		recorder.setHistory(history)
+	}
+}
+
+// checkAndRecord checks the readiness of a component. The outcome of the checks which are not built in is recorded in the
+// component's history, since they do not record it themselves.
+func (rdy *ReadyCheck) checkAndRecord(component ComponentCheck) bool {
+	if isNonBlocking(component) {
+		return component.Ready()
+	}
+
+	start := time.Now()
+	isReady := component.Ready()
+
+	status := StatusReady
+	if !isReady {
+		status = StatusNotReady
+	}
+
+	rdy.recordCheck(component, HistoryEntry{At: start, Status: status, Latency: time.Since(start)})
+
+	return isReady
+}
+
+// recordCheck records the outcome of a check which is not built in in the component's history
+func (rdy *ReadyCheck) recordCheck(component ComponentCheck, entry HistoryEntry) {
+	rdy.componentsMutex.RLock()
+	history, ok := rdy.histories[component.Name()]
+	rdy.componentsMutex.RUnlock()
+
+	if ok {
+		history.record(entry)
+	}
+}
@@ -0,0 +1,62 @@
+package lifecycle_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenChecksAreRecorded_ShouldKeepBoundedHistory(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{HistorySize: 3})
+	push := readycheck.RegisterPushComponent("db")
+
+	errDisconnected := errors.New("disconnected")
+	push.SetReady(true)
+	push.SetError(errDisconnected)
+	push.SetStatus(lifecycle.StatusDegraded)
+	push.SetReady(true)
+
+	history := readycheck.ComponentHistory("db")
+	if assert.Len(history, 3) {
+		assert.Equal(lifecycle.StatusNotReady, history[0].Status)
+		assert.ErrorIs(history[0].Err, errDisconnected)
+		assert.Equal(lifecycle.StatusDegraded, history[1].Status)
+		assert.Equal(lifecycle.StatusReady, history[2].Status)
+		assert.False(history[2].At.Before(history[0].At))
+	}
+
+	assert.Empty(readycheck.ComponentHistory("unknown"))
+}
+
+func Test_WhenCustomCheckIsEvaluated_ShouldRecordLatency(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("slow", slowComponentCheck{name: "slow", delay: 10 * time.Millisecond})
+
+	_ = readycheck.Ready()
+
+	history := readycheck.ComponentHistory("slow")
+	if assert.NotEmpty(history) {
+		assert.Equal(lifecycle.StatusReady, history[len(history)-1].Status)
+		assert.GreaterOrEqual(history[len(history)-1].Latency, 10*time.Millisecond)
+	}
+
+	explanation := readycheck.ExplainVerbose()
+	assert.True(explanation["slow"].Result.Ready)
+	assert.NotEmpty(explanation["slow"].History)
+}
+
+func Test_WhenHistoryIsDisabled_ShouldNotRecordHistory(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{HistorySize: -1})
+	readycheck.RegisterPushComponent("db").SetReady(true)
+
+	assert.Empty(readycheck.ComponentHistory("db"))
+}
@@ -0,0 +1,23 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// RegisterHTTPServer registers an [*http.Server] as a component. When signaled, the server stops accepting new connections and
+// waits for the in-flight requests to complete using [http.Server.Shutdown], bounded by the remaining shutdown time. If the
+// requests do not complete in time, the server is forcefully closed using [http.Server.Close], and the component reports a
+// [ErrShutdownTimeout] error.
+func (gs *GracefulShutdown) RegisterHTTPServer(name string, srv *http.Server, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		err := srv.Shutdown(ctx)
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			_ = srv.Close()
+			return ErrShutdownTimeout
+		}
+
+		return err
+	}, opts)
+}
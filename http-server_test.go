@@ -0,0 +1,164 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func startHTTPServer(t *testing.T, handler http.Handler) (*http.Server, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &http.Server{Handler: handler}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	return srv, "http://" + listener.Addr().String()
+}
+
+func Test_WhenHTTPServerIsRegistered_ShouldWaitForInFlightRequests(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	entered := make(chan struct{})
+	completed := make(chan struct{})
+	srv, url := startHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		close(completed)
+	}))
+
+	assert.NoError(gs.RegisterHTTPServer("http-server", srv))
+
+	go func() {
+		_, _ = http.Get(url)
+	}()
+	<-entered
+
+	err := gs.Shutdown()
+	assert.NoError(err)
+
+	select {
+	case <-completed:
+	default:
+		assert.Fail("in-flight request should have completed before the shutdown")
+	}
+}
+
+func Test_WhenHTTPServerTimesOut_ShouldCloseServer(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	entered := make(chan struct{})
+	srv, url := startHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-r.Context().Done()
+	}))
+
+	assert.NoError(gs.RegisterHTTPServer("http-server", srv))
+
+	requestErr := make(chan error)
+	go func() {
+		_, err := http.Get(url)
+		requestErr <- err
+	}()
+	<-entered
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.True(shutdownErr.IsTimeoutErr())
+		assert.Equal(lifecycle.ErrShutdownTimeout, shutdownErr.ComponentErrors["http-server"])
+	}
+
+	select {
+	case err := <-requestErr:
+		assert.Error(err, "connection should have been closed")
+	case <-time.After(time.Second):
+		assert.Fail("server should have been closed")
+	}
+
+	assert.True(errors.Is(srv.ListenAndServe(), http.ErrServerClosed))
+}
+
+func Test_WhenHTTPServerIsRegisteredInPhase_ShouldShutdownBeforeLowerPhases(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	srv, _ := startHTTPServer(t, http.NotFoundHandler())
+	assert.NoError(gs.RegisterHTTPServer("http-server", srv, lifecycle.InPhase(10)))
+
+	serverClosed := false
+	_ = gs.RegisterComponentWithFn("db-pool", func() error {
+		serverClosed = errors.Is(srv.ListenAndServe(), http.ErrServerClosed)
+		return nil
+	})
+
+	assert.NoError(gs.Shutdown())
+	assert.True(serverClosed, "server should be closed before the db-pool shuts down")
+}
+
+func Test_WhenHTTPServerReportsReadiness_ShouldBeNotReadyOnceSignaled(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	readycheck := lifecycle.NewReadyCheck()
+
+	srv, _ := startHTTPServer(t, http.NotFoundHandler())
+	assert.NoError(gs.RegisterHTTPServer("http-server", srv, lifecycle.InPhase(10), lifecycle.ReportReadiness(readycheck)))
+	assert.True(readycheck.Ready(), "server should be ready once registered")
+
+	readyDuringDrain := true
+	_ = gs.RegisterComponentWithFn("db-pool", func() error {
+		readyDuringDrain = readycheck.Explain()["http-server"]
+		return nil
+	})
+
+	assert.NoError(gs.Shutdown())
+	assert.False(readyDuringDrain, "server should be reported as not ready once signaled")
+	assert.False(readycheck.Ready())
+}
+
+func Test_WhenComponentReportingReadinessIsUnregistered_ShouldRemoveReadiness(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	readycheck := lifecycle.NewReadyCheck()
+
+	assert.NoError(gs.RegisterComponentWithFn("worker", func() error {
+		return nil
+	}, lifecycle.ReportReadiness(readycheck)))
+	assert.Equal(map[string]bool{"worker": true}, readycheck.Explain())
+
+	assert.NoError(gs.UnregisterComponent("worker"))
+	assert.Empty(readycheck.Explain())
+}
+
+func Test_WhenComponentReportingReadinessIsUnregistered_ShouldKeepOtherChecksWithSameName(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	readycheck := lifecycle.NewReadyCheck()
+
+	assert.NoError(gs.RegisterComponentWithFn("worker", func() error {
+		return nil
+	}, lifecycle.ReportReadiness(readycheck)))
+	worker := readycheck.RegisterPushComponent("worker")
+
+	assert.NoError(gs.UnregisterComponent("worker"))
+	assert.Equal(map[string]bool{"worker": false}, readycheck.Explain(), "the check registered by the user should be kept")
+
+	worker.SetReady(true)
+	assert.True(readycheck.Ready())
+}
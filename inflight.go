@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// InFlightTracker counts the requests being processed by a server, so that the shutdown can wait for them to complete. Once it
+// stops accepting, new requests are rejected.
+type InFlightTracker struct {
+	mutex *sync.Mutex
+
+	count     int
+	accepting bool
+	idle      chan struct{}
+}
+
+// NewInFlightTracker creates a new instance of [*InFlightTracker]
+func NewInFlightTracker() *InFlightTracker {
+	return &InFlightTracker{
+		mutex:     &sync.Mutex{},
+		accepting: true,
+	}
+}
+
+// Acquire records a new in-flight request. It returns false if the tracker no longer accepts requests, in which case the
+// request must be rejected and [InFlightTracker.Release] must not be called.
+func (t *InFlightTracker) Acquire() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.accepting {
+		return false
+	}
+
+	t.count++
+	if t.count == 1 {
+		t.idle = make(chan struct{})
+	}
+
+	return true
+}
+
+// Release records the completion of an in-flight request
+func (t *InFlightTracker) Release() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.count--
+	if t.count == 0 {
+		close(t.idle)
+	}
+}
+
+// InFlight returns the number of requests currently being processed
+func (t *InFlightTracker) InFlight() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.count
+}
+
+// StopAccepting makes the tracker reject every new request
+func (t *InFlightTracker) StopAccepting() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.accepting = false
+}
+
+// Drain stops accepting new requests, then blocks until the in-flight requests complete or the context is done
+func (t *InFlightTracker) Drain(ctx context.Context) error {
+	t.mutex.Lock()
+	t.accepting = false
+	if t.count == 0 {
+		t.mutex.Unlock()
+		return nil
+	}
+	idle := t.idle
+	t.mutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// HTTPMiddleware tracks the requests handled by the next handler. Once the tracker stops accepting, new requests are rejected
+// with a 503 Service Unavailable status.
+func (t *InFlightTracker) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !t.Acquire() {
+			w.Header().Set("Connection", "close")
+			http.Error(w, "service is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		defer t.Release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RegisterInFlightTracker registers an [*InFlightTracker] as a component. When signaled, the tracker stops accepting new
+// requests, then the component waits for the in-flight requests to complete, bounded by the remaining shutdown time. Once the
+// component is unregistered, the tracker is left untouched by the shutdown.
+func (gs *GracefulShutdown) RegisterInFlightTracker(name string, tracker *InFlightTracker, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, tracker.Drain, opts)
+}
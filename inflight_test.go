@@ -0,0 +1,79 @@
+package lifecycle_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenInFlightTrackerIsRegistered_ShouldWaitForInFlightRequests(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	tracker := lifecycle.NewInFlightTracker()
+	assert.NoError(gs.RegisterInFlightTracker("http-requests", tracker))
+
+	release := make(chan struct{})
+	handler := tracker.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	inFlight := httptest.NewRecorder()
+	go handler.ServeHTTP(inFlight, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Eventually(func() bool { return tracker.InFlight() == 1 }, time.Second, 5*time.Millisecond)
+
+	shutdownErr := make(chan error)
+	go func() {
+		shutdownErr <- gs.Shutdown()
+	}()
+	<-gs.Draining()
+	time.Sleep(20 * time.Millisecond)
+
+	rejected := httptest.NewRecorder()
+	handler.ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(http.StatusServiceUnavailable, rejected.Code, "new requests should be rejected while draining")
+
+	select {
+	case <-shutdownErr:
+		assert.Fail("shutdown should wait for the in-flight request")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(<-shutdownErr)
+	assert.Equal(0, tracker.InFlight())
+}
+
+func Test_WhenInFlightRequestsDoNotComplete_ShouldTimeout(t *testing.T) {
+	assert := assert2.New(t)
+
+	tracker := lifecycle.NewInFlightTracker()
+	assert.True(tracker.Acquire())
+	defer tracker.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(tracker.Drain(ctx), context.DeadlineExceeded)
+	assert.False(tracker.Acquire(), "drained tracker should not accept requests")
+}
+
+func Test_WhenInFlightTrackerIsUnregistered_ShouldKeepAcceptingAfterShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	tracker := lifecycle.NewInFlightTracker()
+	assert.NoError(gs.RegisterInFlightTracker("http-requests", tracker))
+	assert.NoError(gs.UnregisterComponent("http-requests"))
+
+	assert.NoError(gs.Shutdown())
+
+	assert.True(tracker.Acquire(), "unregistered tracker should not be affected by the shutdown")
+	tracker.Release()
+}
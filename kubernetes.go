@@ -0,0 +1,64 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+var (
+	// DefaultKubernetesTerminationGracePeriod is the default terminationGracePeriodSeconds of a Kubernetes pod
+	DefaultKubernetesTerminationGracePeriod = 30 * time.Second
+	// DefaultKubernetesPreShutdownDelay is the time given to Kubernetes to remove the pod from the Service endpoints
+	DefaultKubernetesPreShutdownDelay = 5 * time.Second
+)
+
+// TerminationGracePeriodEnv is the environment variable read by [KubernetesOptions] to determine the pod's termination grace
+// period, in seconds. Kubernetes does not expose it to the container, so it must be set in the pod spec to the same value as
+// terminationGracePeriodSeconds.
+const TerminationGracePeriodEnv = "TERMINATION_GRACE_PERIOD_SECONDS"
+
+// KubernetesOptions returns [GracefulShutdownOptions] tuned for Kubernetes pods:
+//   - the shutdown is triggered by SIGTERM, which Kubernetes sends when terminating the pod
+//   - a pre-shutdown delay gives Kubernetes time to stop routing traffic to the pod before the components are signaled
+//   - the timeout and the finalizer timeout fit in the termination grace period, read from the [TerminationGracePeriodEnv]
+//     environment variable, keeping a margin for the process to exit before it is killed
+func KubernetesOptions() GracefulShutdownOptions {
+	gracePeriod := DefaultKubernetesTerminationGracePeriod
+	if seconds, err := strconv.Atoi(os.Getenv(TerminationGracePeriodEnv)); err == nil && seconds > 0 {
+		gracePeriod = time.Duration(seconds) * time.Second
+	}
+
+	preShutdownDelay := DefaultKubernetesPreShutdownDelay
+	if preShutdownDelay > gracePeriod/4 {
+		preShutdownDelay = gracePeriod / 4
+	}
+
+	margin := gracePeriod / 10
+
+	finalizerTimeout := DefaultFinalizerTimeout
+	if finalizerTimeout > gracePeriod/10 {
+		finalizerTimeout = gracePeriod / 10
+	}
+
+	return GracefulShutdownOptions{
+		Signals:          []os.Signal{syscall.SIGTERM, os.Interrupt},
+		PreShutdownDelay: preShutdownDelay,
+		Timeout:          gracePeriod - preShutdownDelay - finalizerTimeout - margin,
+		FinalizerTimeout: finalizerTimeout,
+	}
+}
+
+// NewKubernetesGracefulShutdown creates a new instance of [*GracefulShutdown] using [KubernetesOptions]. When a [ReadyCheck]
+// is given, it is attached so the pod reports itself as not ready as soon as SIGTERM is received. See
+// [GracefulShutdown.AttachReadyCheck].
+func NewKubernetesGracefulShutdown(ctx context.Context, rdy *ReadyCheck) *GracefulShutdown {
+	gs := NewGracefulShutdownWithOptions(ctx, KubernetesOptions())
+	if rdy != nil {
+		gs.AttachReadyCheck(rdy)
+	}
+
+	return gs
+}
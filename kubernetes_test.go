@@ -0,0 +1,66 @@
+package lifecycle_test
+
+import (
+	"context"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenUsingKubernetesOptions_ShouldFitInDefaultGracePeriod(t *testing.T) {
+	assert := assert2.New(t)
+	t.Setenv(lifecycle.TerminationGracePeriodEnv, "")
+
+	options := lifecycle.KubernetesOptions()
+
+	assert.Contains(options.Signals, syscall.SIGTERM)
+	assert.Equal(5*time.Second, options.PreShutdownDelay)
+	assert.Equal(19*time.Second, options.Timeout)
+	assert.Equal(3*time.Second, options.FinalizerTimeout)
+}
+
+func Test_WhenTerminationGracePeriodIsSet_ShouldDeriveTimeout(t *testing.T) {
+	assert := assert2.New(t)
+	t.Setenv(lifecycle.TerminationGracePeriodEnv, "10")
+
+	options := lifecycle.KubernetesOptions()
+
+	assert.Equal(2500*time.Millisecond, options.PreShutdownDelay)
+	assert.Equal(5500*time.Millisecond, options.Timeout)
+	assert.Equal(time.Second, options.FinalizerTimeout)
+}
+
+func Test_WhenUsingKubernetesOptions_ShouldFinalizeWithinGracePeriod(t *testing.T) {
+	assert := assert2.New(t)
+
+	for _, seconds := range []int{1, 10, 30, 60, 300} {
+		t.Setenv(lifecycle.TerminationGracePeriodEnv, strconv.Itoa(seconds))
+		gracePeriod := time.Duration(seconds) * time.Second
+
+		options := lifecycle.KubernetesOptions()
+
+		total := options.PreShutdownDelay + options.Timeout + options.FinalizerTimeout
+		assert.Less(total, gracePeriod, "shutdown and finalizers should fit in a %s grace period", gracePeriod)
+		assert.Positive(options.Timeout)
+		assert.Positive(options.FinalizerTimeout)
+	}
+}
+
+func Test_WhenKubernetesGracefulShutdownIsCreated_ShouldAttachReadyCheck(t *testing.T) {
+	assert := assert2.New(t)
+	t.Setenv(lifecycle.TerminationGracePeriodEnv, "1")
+
+	rdy := lifecycle.NewReadyCheck()
+	gs := lifecycle.NewKubernetesGracefulShutdown(context.Background(), rdy)
+
+	go func() {
+		_ = gs.Shutdown()
+	}()
+	<-gs.Draining()
+
+	assert.Eventually(rdy.Draining, time.Second, 5*time.Millisecond, "ready check should drain during the pre-shutdown delay")
+}
@@ -0,0 +1,151 @@
+package lifecycle
+
+import (
+	"bytes"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// leakPollInterval is the delay between each check for leaked goroutines, while waiting for them to exit
+const leakPollInterval = 10 * time.Millisecond
+
+// ignoredGoroutines lists the goroutines started by the runtime or by this package which may legitimately outlive the drain
+var ignoredGoroutines = []string{
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"github.com/gretro/go-lifecycle.(*GracefulShutdown).WaitForShutdown.func",
+	"github.com/gretro/go-lifecycle.withTimeout.func",
+}
+
+// GoroutineLeak is a goroutine which was still running once the shutdown completed, and which was not running when the baseline
+// was captured. See [GracefulShutdownOptions.DetectGoroutineLeaks].
+type GoroutineLeak struct {
+	// ID is the identifier of the goroutine, as printed in its stack
+	ID uint64
+	// Stack is the stack trace of the goroutine
+	Stack string
+}
+
+// goroutine is a goroutine parsed from the output of runtime.Stack
+type goroutine struct {
+	id    uint64
+	stack string
+}
+
+// captureGoroutineBaseline records the goroutines currently running, when the leak detection is enabled. The component mutex
+// must be held.
+func (gs *GracefulShutdown) captureGoroutineBaseline() {
+	if !gs.options.DetectGoroutineLeaks {
+		return
+	}
+
+	goroutines := parseGoroutines(goroutineDump())
+
+	gs.goroutineBaseline = make(map[uint64]bool, len(goroutines))
+	for _, g := range goroutines {
+		gs.goroutineBaseline[g.id] = true
+	}
+}
+
+// detectGoroutineLeaks waits up to the GoroutineLeakGracePeriod for the goroutines started since the baseline to exit, and
+// records the ones still running in the shutdown report. The grace period is measured with the Clock.
+func (gs *GracefulShutdown) detectGoroutineLeaks() {
+	if !gs.options.DetectGoroutineLeaks {
+		return
+	}
+
+	gs.componentMutex.RLock()
+	baseline := gs.goroutineBaseline
+	gs.componentMutex.RUnlock()
+
+	current := currentGoroutineID()
+	deadline := gs.options.Clock.Now().Add(gs.options.GoroutineLeakGracePeriod)
+
+	for {
+		leaks := gs.findGoroutineLeaks(baseline, current)
+		if len(leaks) == 0 || !gs.options.Clock.Now().Before(deadline) {
+			gs.progressTracker.setLeaks(leaks)
+			return
+		}
+
+		<-gs.options.Clock.After(leakPollInterval)
+	}
+}
+
+func (gs *GracefulShutdown) findGoroutineLeaks(baseline map[uint64]bool, current uint64) []GoroutineLeak {
+	leaks := make([]GoroutineLeak, 0)
+
+	for _, g := range parseGoroutines(goroutineDump()) {
+		if baseline[g.id] || g.id == current || gs.isIgnoredGoroutine(g.stack) {
+			continue
+		}
+
+		leaks = append(leaks, GoroutineLeak{ID: g.id, Stack: g.stack})
+	}
+
+	sort.Slice(leaks, func(i, j int) bool {
+		return leaks[i].ID < leaks[j].ID
+	})
+
+	return leaks
+}
+
+func (gs *GracefulShutdown) isIgnoredGoroutine(stack string) bool {
+	for _, ignored := range ignoredGoroutines {
+		if strings.Contains(stack, ignored) {
+			return true
+		}
+	}
+
+	for _, ignored := range gs.options.IgnoreGoroutines {
+		if strings.Contains(stack, ignored) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseGoroutines splits the output of runtime.Stack into the goroutines it lists
+func parseGoroutines(dump []byte) []goroutine {
+	blocks := bytes.Split(bytes.TrimSpace(dump), []byte("\n\n"))
+	goroutines := make([]goroutine, 0, len(blocks))
+
+	for _, block := range blocks {
+		id, ok := parseGoroutineID(block)
+		if !ok {
+			continue
+		}
+
+		goroutines = append(goroutines, goroutine{id: id, stack: string(block)})
+	}
+
+	return goroutines
+}
+
+// parseGoroutineID reads the identifier from the header of a goroutine's stack, such as "goroutine 12 [running]:"
+func parseGoroutineID(stack []byte) (uint64, bool) {
+	fields := bytes.Fields(stack)
+	if len(fields) < 2 || string(fields[0]) != "goroutine" {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// currentGoroutineID returns the identifier of the calling goroutine
+func currentGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	n := runtime.Stack(buf, false)
+
+	id, _ := parseGoroutineID(buf[:n])
+	return id
+}
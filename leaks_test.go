@@ -0,0 +1,143 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+// leaksOf returns the leaks whose stack mentions the test, ignoring the goroutines left behind by other tests
+func leaksOf(t *testing.T, report lifecycle.ShutdownReport) []lifecycle.GoroutineLeak {
+	leaks := make([]lifecycle.GoroutineLeak, 0)
+	for _, leak := range report.Leaks {
+		if strings.Contains(leak.Stack, t.Name()) {
+			leaks = append(leaks, leak)
+		}
+	}
+
+	return leaks
+}
+
+func Test_WhenGoroutineOutlivesShutdown_ShouldReportLeak(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		DetectGoroutineLeaks:     true,
+		GoroutineLeakGracePeriod: 50 * time.Millisecond,
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	_ = gs.RegisterComponentWithFn("leaky", func() error {
+		go func() {
+			<-release
+		}()
+
+		return nil
+	})
+
+	report, err := gs.ShutdownWithReport()
+	assert.NoError(err)
+
+	leaks := leaksOf(t, report)
+	if !assert.Len(leaks, 1) {
+		return
+	}
+
+	assert.NotZero(leaks[0].ID)
+	assert.True(strings.HasPrefix(leaks[0].Stack, "goroutine "))
+}
+
+func Test_WhenGoroutinesExitWithinGracePeriod_ShouldNotReportLeak(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		DetectGoroutineLeaks:     true,
+		GoroutineLeakGracePeriod: time.Second,
+	})
+
+	_ = gs.RegisterComponentWithFn("tidy", func() error {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+		}()
+
+		return nil
+	})
+
+	report, err := gs.ShutdownWithReport()
+	assert.NoError(err)
+	assert.Empty(leaksOf(t, report))
+}
+
+func Test_WhenLeakDetectionDisabled_ShouldNotReportLeak(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+
+	_ = gs.RegisterComponentWithFn("leaky", func() error {
+		go func() {
+			<-release
+		}()
+
+		return nil
+	})
+
+	report, err := gs.ShutdownWithReport()
+	assert.NoError(err)
+	assert.Empty(report.Leaks)
+}
+
+func Test_WhenShutdownFails_ShouldReportLeaksInError(t *testing.T) {
+	assert := assert2.New(t)
+	clock := lifecycletest.NewClock(time.Now())
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Clock:                    clock,
+		DetectGoroutineLeaks:     true,
+		GoroutineLeakGracePeriod: time.Second,
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	_ = gs.RegisterComponentWithFn("leaky", func() error {
+		go func() {
+			<-release
+		}()
+
+		return errors.New("failed to close")
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	// The shutdown timeout, then the first poll of the leak detection
+	<-clock.TimersCreated(2)
+
+	select {
+	case <-done:
+		assert.Fail("the grace period should be measured with the clock")
+		return
+	default:
+	}
+
+	clock.Advance(time.Second)
+
+	err := <-done
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr) {
+		return
+	}
+
+	assert.Len(leaksOf(t, shutdownErr.Report), 1)
+	assert.Len(leaksOf(t, gs.Report()), 1)
+}
@@ -0,0 +1,300 @@
+// Package lifecycleconsul registers a service and its checks with the local Consul agent, keeps its TTL check updated from a
+// [*lifecycle.PulseComponentCheck], and deregisters the service on shutdown. It talks to the agent's HTTP API, so it does not
+// depend on the Consul client module.
+package lifecycleconsul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+)
+
+var (
+	DefaultAgentAddress = "http://127.0.0.1:8500"
+	DefaultTTL          = 15 * time.Second
+	DefaultTimeout      = 5 * time.Second
+)
+
+// RegistrationOptions are options used in conjunction with the [Register] function
+type RegistrationOptions struct {
+	// AgentAddress is the address of the Consul agent's HTTP API
+	//
+	// Default: the CONSUL_HTTP_ADDR environment variable, or http://127.0.0.1:8500
+	AgentAddress string
+	// Token is the ACL token sent to the agent
+	//
+	// Default: the CONSUL_HTTP_TOKEN environment variable
+	Token string
+	// Client is the client sending the requests to the agent
+	//
+	// Default: http.DefaultClient
+	Client *http.Client
+	// Timeout is the time allocated to each request sent to the agent
+	//
+	// Default: 5s
+	Timeout time.Duration
+
+	// ServiceID is the unique identifier of the service instance
+	//
+	// Default: ServiceName
+	ServiceID string
+	// ServiceName is the name of the service
+	ServiceName string
+	// Address is the address of the service instance
+	Address string
+	// Port is the port of the service instance
+	Port int
+	// Tags are the tags of the service instance
+	Tags []string
+
+	// Pulse keeps the TTL check of the service passing while it is ready. The TTL check is omitted when nil.
+	Pulse *lifecycle.PulseComponentCheck
+	// TTL is the time after which the agent marks the TTL check as critical if it was not updated. The check is updated every
+	// half TTL.
+	//
+	// Default: 15s
+	TTL time.Duration
+
+	// HTTPCheckURL is an URL polled by the agent to check the health of the service, typically served by
+	// [lifecycle.ReadyCheck.Handler]. The HTTP check is omitted when empty.
+	HTTPCheckURL string
+	// HTTPCheckInterval is the interval at which the agent polls the HTTPCheckURL
+	//
+	// Default: 10s
+	HTTPCheckInterval time.Duration
+}
+
+var DefaultHTTPCheckInterval = 10 * time.Second
+
+// Registration is a service registered with the Consul agent. Closing it stops updating its TTL check and deregisters the
+// service.
+type Registration struct {
+	options    RegistrationOptions
+	ttlCheckID string
+
+	stopOnce *sync.Once
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+type agentCheck struct {
+	CheckID  string `json:"CheckID,omitempty"`
+	Name     string `json:"Name"`
+	TTL      string `json:"TTL,omitempty"`
+	HTTP     string `json:"HTTP,omitempty"`
+	Interval string `json:"Interval,omitempty"`
+}
+
+type agentService struct {
+	ID      string       `json:"ID"`
+	Name    string       `json:"Name"`
+	Address string       `json:"Address,omitempty"`
+	Port    int          `json:"Port,omitempty"`
+	Tags    []string     `json:"Tags,omitempty"`
+	Checks  []agentCheck `json:"Checks,omitempty"`
+}
+
+// Register registers the service with the Consul agent, starts updating its TTL check, and registers the [Registration] as a
+// component of the [lifecycle.GracefulShutdown], so that the service is deregistered on shutdown. Register the component in a
+// phase drained before the servers, for instance with [lifecycle.InPhase], to stop receiving traffic before draining it.
+func Register(ctx context.Context, gs *lifecycle.GracefulShutdown, options RegistrationOptions, opts ...lifecycle.ComponentOption) (*Registration, error) {
+	registration, err := NewRegistration(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gs.RegisterCloser("consul:"+registration.options.ServiceID, registration, opts...); err != nil {
+		_ = registration.Close()
+		return nil, err
+	}
+
+	return registration, nil
+}
+
+// NewRegistration registers the service with the Consul agent and starts updating its TTL check. The [Registration] must be
+// closed to deregister the service.
+func NewRegistration(ctx context.Context, options RegistrationOptions) (*Registration, error) {
+	if options.ServiceName == "" {
+		return nil, errors.New("service name is required")
+	}
+
+	if options.AgentAddress == "" {
+		options.AgentAddress = os.Getenv("CONSUL_HTTP_ADDR")
+	}
+	if options.AgentAddress == "" {
+		options.AgentAddress = DefaultAgentAddress
+	}
+
+	if options.Token == "" {
+		options.Token = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	if options.ServiceID == "" {
+		options.ServiceID = options.ServiceName
+	}
+
+	if options.TTL <= 0 {
+		options.TTL = DefaultTTL
+	}
+
+	if options.HTTPCheckInterval <= 0 {
+		options.HTTPCheckInterval = DefaultHTTPCheckInterval
+	}
+
+	registration := &Registration{
+		options:  options,
+		stopOnce: &sync.Once{},
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	service := agentService{
+		ID:      options.ServiceID,
+		Name:    options.ServiceName,
+		Address: options.Address,
+		Port:    options.Port,
+		Tags:    options.Tags,
+	}
+
+	if options.Pulse != nil {
+		registration.ttlCheckID = "service:" + options.ServiceID + ":ttl"
+		service.Checks = append(service.Checks, agentCheck{
+			CheckID: registration.ttlCheckID,
+			Name:    options.Pulse.Name(),
+			TTL:     options.TTL.String(),
+		})
+	}
+
+	if options.HTTPCheckURL != "" {
+		service.Checks = append(service.Checks, agentCheck{
+			Name:     options.ServiceName + " health",
+			HTTP:     options.HTTPCheckURL,
+			Interval: options.HTTPCheckInterval.String(),
+		})
+	}
+
+	if err := registration.put(ctx, "/v1/agent/service/register", service); err != nil {
+		return nil, fmt.Errorf("unable to register service %q: %w", options.ServiceID, err)
+	}
+
+	if options.Pulse != nil {
+		go registration.updateTTL()
+	} else {
+		close(registration.stopped)
+	}
+
+	return registration, nil
+}
+
+// updateTTL updates the TTL check every half TTL, until the registration is closed
+func (registration *Registration) updateTTL() {
+	defer close(registration.stopped)
+
+	ticker := time.NewTicker(registration.options.TTL / 2)
+	defer ticker.Stop()
+
+	for {
+		registration.reportTTL()
+
+		select {
+		case <-registration.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (registration *Registration) reportTTL() {
+	ctx, cancel := context.WithTimeout(context.Background(), registration.options.Timeout)
+	defer cancel()
+
+	result := registration.options.Pulse.Result()
+
+	status := "pass"
+	note := ""
+	if !result.Ready {
+		status = "fail"
+		if result.Err != nil {
+			note = result.Err.Error()
+		}
+	}
+
+	path := "/v1/agent/check/" + status + "/" + url.PathEscape(registration.ttlCheckID)
+	if note != "" {
+		path += "?note=" + url.QueryEscape(note)
+	}
+
+	// A failed update is retried on the next tick, and the agent marks the check as critical if it keeps failing
+	_ = registration.put(ctx, path, nil)
+}
+
+// Close stops updating the TTL check and deregisters the service from the Consul agent
+func (registration *Registration) Close() error {
+	registration.stopOnce.Do(func() {
+		close(registration.stop)
+	})
+	<-registration.stopped
+
+	ctx, cancel := context.WithTimeout(context.Background(), registration.options.Timeout)
+	defer cancel()
+
+	if err := registration.put(ctx, "/v1/agent/service/deregister/"+url.PathEscape(registration.options.ServiceID), nil); err != nil {
+		return fmt.Errorf("unable to deregister service %q: %w", registration.options.ServiceID, err)
+	}
+
+	return nil
+}
+
+func (registration *Registration) put(ctx context.Context, path string, body any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, registration.options.AgentAddress+path, reader)
+	if err != nil {
+		return err
+	}
+
+	if registration.options.Token != "" {
+		req.Header.Set("X-Consul-Token", registration.options.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := registration.options.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	message, _ := io.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("agent responded with status %d: %s", res.StatusCode, bytes.TrimSpace(message))
+	}
+
+	return nil
+}
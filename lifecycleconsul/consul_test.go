@@ -0,0 +1,133 @@
+package lifecycleconsul_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycleconsul"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type agentRequest struct {
+	path  string
+	token string
+	body  map[string]any
+}
+
+type fakeAgent struct {
+	mutex    *sync.Mutex
+	requests []agentRequest
+}
+
+func startFakeAgent(t *testing.T) (*fakeAgent, string) {
+	agent := &fakeAgent{mutex: &sync.Mutex{}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := agentRequest{path: r.URL.RequestURI(), token: r.Header.Get("X-Consul-Token")}
+		if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+			_ = json.Unmarshal(body, &request.body)
+		}
+
+		agent.mutex.Lock()
+		agent.requests = append(agent.requests, request)
+		agent.mutex.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	return agent, server.URL
+}
+
+func (agent *fakeAgent) paths() []string {
+	agent.mutex.Lock()
+	defer agent.mutex.Unlock()
+
+	paths := make([]string, 0, len(agent.requests))
+	for _, request := range agent.requests {
+		paths = append(paths, request.path)
+	}
+
+	return paths
+}
+
+func Test_WhenRegistered_ShouldRegisterServiceAndUpdateTTL(t *testing.T) {
+	assert := assert2.New(t)
+	agent, address := startFakeAgent(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	pulse := readycheck.RegisterPulseComponent("heartbeat", time.Second)
+	pulse.RecordPulse()
+
+	registration, err := lifecycleconsul.NewRegistration(context.Background(), lifecycleconsul.RegistrationOptions{
+		AgentAddress: address,
+		Token:        "secret",
+		ServiceName:  "orders",
+		Port:         8080,
+		Pulse:        pulse,
+		TTL:          20 * time.Millisecond,
+		HTTPCheckURL: "http://localhost:8080/ready",
+	})
+	if !assert.NoError(err) {
+		return
+	}
+
+	assert.Eventually(func() bool {
+		return len(agent.paths()) >= 3
+	}, time.Second, time.Millisecond)
+
+	agent.mutex.Lock()
+	register := agent.requests[0]
+	agent.mutex.Unlock()
+
+	assert.Equal("/v1/agent/service/register", register.path)
+	assert.Equal("secret", register.token)
+	assert.Equal("orders", register.body["ID"])
+	assert.Len(register.body["Checks"], 2)
+
+	assert.NoError(registration.Close())
+
+	paths := agent.paths()
+	assert.Contains(paths, "/v1/agent/check/pass/service:orders:ttl")
+	assert.Equal("/v1/agent/service/deregister/orders", paths[len(paths)-1])
+}
+
+func Test_WhenPulseExpired_ShouldFailTTLCheck(t *testing.T) {
+	assert := assert2.New(t)
+	agent, address := startFakeAgent(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	pulse := readycheck.RegisterPulseComponent("heartbeat", time.Second)
+
+	registration, err := lifecycleconsul.NewRegistration(context.Background(), lifecycleconsul.RegistrationOptions{
+		AgentAddress: address,
+		ServiceName:  "orders",
+		Pulse:        pulse,
+	})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.NoError(registration.Close())
+
+	assert.Contains(agent.paths(), "/v1/agent/check/fail/service:orders:ttl?note=no+pulse+was+recorded")
+}
+
+func Test_WhenShutdown_ShouldDeregisterService(t *testing.T) {
+	assert := assert2.New(t)
+	agent, address := startFakeAgent(t)
+
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	_, err := lifecycleconsul.Register(context.Background(), gs, lifecycleconsul.RegistrationOptions{
+		AgentAddress: address,
+		ServiceName:  "orders",
+	}, lifecycle.InPhase(10))
+	assert.NoError(err)
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal([]string{"/v1/agent/service/register", "/v1/agent/service/deregister/orders"}, agent.paths())
+}
@@ -0,0 +1,24 @@
+module github.com/gretro/go-lifecycle/lifecyclegrpc
+
+go 1.20
+
+require (
+	github.com/gretro/go-lifecycle v0.0.0-20261016112221-8ef3bfae90f5
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.59.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.14.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+	golang.org/x/text v0.12.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230822172742-b8732ec3820d // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The core module is built from this repository during development. Consumers resolve the version required above.
+replace github.com/gretro/go-lifecycle => ../
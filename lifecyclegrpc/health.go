@@ -0,0 +1,100 @@
+package lifecyclegrpc
+
+import (
+	"context"
+
+	"github.com/gretro/go-lifecycle"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthServer implements the gRPC Health Checking Protocol (grpc.health.v1.Health) backed by a [*lifecycle.ReadyCheck]. The
+// empty service name reports the overall readiness, while a component's name reports the readiness of that component.
+type HealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	rdy *lifecycle.ReadyCheck
+}
+
+// NewHealthServer creates a new instance of [*HealthServer]. Register it on a gRPC server with healthpb.RegisterHealthServer.
+func NewHealthServer(rdy *lifecycle.ReadyCheck) *HealthServer {
+	return &HealthServer{
+		rdy: rdy,
+	}
+}
+
+// readiness returns the readiness of the service: the overall readiness for the empty service name, or the readiness of the
+// component with that name regardless of the drain mode. It returns false if no component has that name.
+func (srv *HealthServer) readiness(service string) (isReady bool, known bool) {
+	if service == "" {
+		return srv.rdy.Ready(), true
+	}
+
+	isReady, known = srv.rdy.Explain()[service]
+	return isReady, known
+}
+
+func (srv *HealthServer) servingStatus(service string, isReady bool, known bool) healthpb.HealthCheckResponse_ServingStatus {
+	if !known {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	// The overall readiness already accounts for the drain mode
+	if isReady && (service == "" || !srv.rdy.Draining()) {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func (srv *HealthServer) status(service string) healthpb.HealthCheckResponse_ServingStatus {
+	isReady, known := srv.readiness(service)
+
+	return srv.servingStatus(service, isReady, known)
+}
+
+// Check returns the serving status of the requested service. Unknown services cause a NOT_FOUND error.
+func (srv *HealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus := srv.status(req.GetService())
+	if servingStatus == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// Watch streams the serving status of the requested service: the current status is sent immediately, then every time it
+// changes. The changes are received from [lifecycle.ReadyCheck.Subscribe], so the checks are not evaluated again while
+// watching. Unknown services are reported as SERVICE_UNKNOWN.
+func (srv *HealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	service := req.GetService()
+
+	events := srv.rdy.Subscribe()
+	defer srv.rdy.Unsubscribe(events)
+
+	isReady, known := srv.readiness(service)
+	lastStatus := healthpb.HealthCheckResponse_ServingStatus(-1)
+
+	for {
+		servingStatus := srv.servingStatus(service, isReady, known)
+		if servingStatus != lastStatus {
+			err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus})
+			if err != nil {
+				return status.Error(codes.Canceled, "stream has ended")
+			}
+
+			lastStatus = servingStatus
+		}
+
+		select {
+		case event := <-events:
+			// A change of the overall readiness may come from the drain mode, which also applies to the components
+			if event.Component == service {
+				isReady, known = event.Ready, true
+			}
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package lifecyclegrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecyclegrpc"
+	assert2 "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startHealthServer(t *testing.T, rdy *lifecycle.ReadyCheck) healthpb.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, lifecyclegrpc.NewHealthServer(rdy))
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func Test_WhenCheckingHealth_ShouldReportReadiness(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPushComponent("db").SetReady(true)
+	rdy.RegisterPushComponent("cache")
+	client := startHealthServer(t, rdy)
+
+	res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	res, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "db"})
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(codes.NotFound, status.Code(err))
+}
+
+func Test_WhenWatchingHealth_ShouldStreamTransitions(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	push := rdy.RegisterPushComponent("db")
+	client := startHealthServer(t, rdy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if !assert.NoError(err) {
+		return
+	}
+
+	res, err := stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	push.SetReady(true)
+	res, err = stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	rdy.SetDraining(true)
+	res, err = stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
+
+func Test_WhenWatchingComponent_ShouldStreamItsTransitions(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	db := rdy.RegisterPushComponent("db")
+	cache := rdy.RegisterPushComponent("cache")
+	client := startHealthServer(t, rdy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "db"})
+	if !assert.NoError(err) {
+		return
+	}
+
+	res, err := stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	cache.SetReady(true)
+	db.SetReady(true)
+	res, err = stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_SERVING, res.GetStatus(), "only changes of the component should be sent")
+
+	cache.SetReady(false)
+	rdy.SetDraining(true)
+	res, err = stream.Recv()
+	assert.NoError(err)
+	assert.Equal(healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
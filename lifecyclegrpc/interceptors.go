@@ -0,0 +1,42 @@
+// Package lifecyclegrpc integrates gRPC servers with the lifecycle of an application.
+package lifecyclegrpc
+
+import (
+	"context"
+
+	"github.com/gretro/go-lifecycle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func errShuttingDown() error {
+	return status.Error(codes.Unavailable, "server is shutting down")
+}
+
+// UnaryServerInterceptor tracks the active unary RPCs with the given [*lifecycle.InFlightTracker]. Once the tracker stops
+// accepting, new RPCs are refused with the UNAVAILABLE code. Register the tracker with
+// [lifecycle.GracefulShutdown.RegisterInFlightTracker] so the shutdown waits for the active RPCs to drain.
+func UnaryServerInterceptor(tracker *lifecycle.InFlightTracker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !tracker.Acquire() {
+			return nil, errShuttingDown()
+		}
+		defer tracker.Release()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor tracks the active streaming RPCs with the given [*lifecycle.InFlightTracker]. Once the tracker stops
+// accepting, new RPCs are refused with the UNAVAILABLE code.
+func StreamServerInterceptor(tracker *lifecycle.InFlightTracker) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !tracker.Acquire() {
+			return errShuttingDown()
+		}
+		defer tracker.Release()
+
+		return handler(srv, stream)
+	}
+}
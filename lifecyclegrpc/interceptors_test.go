@@ -0,0 +1,80 @@
+package lifecyclegrpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecyclegrpc"
+	assert2 "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_WhenShutdownIsRequested_ShouldRefuseNewRPCsAndDrainActiveOnes(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	tracker := lifecycle.NewInFlightTracker()
+	assert.NoError(gs.RegisterInFlightTracker("grpc-requests", tracker))
+	interceptor := lifecyclegrpc.UnaryServerInterceptor(tracker)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}
+
+	release := make(chan struct{})
+	go func() {
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			<-release
+			return nil, nil
+		})
+	}()
+	assert.Eventually(func() bool { return tracker.InFlight() == 1 }, time.Second, 5*time.Millisecond)
+
+	shutdownErr := make(chan error)
+	go func() {
+		shutdownErr <- gs.Shutdown()
+	}()
+	// The tracker stops accepting once it is signaled
+	assert.Eventually(func() bool {
+		if tracker.Acquire() {
+			tracker.Release()
+			return false
+		}
+
+		return true
+	}, time.Second, 5*time.Millisecond)
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		assert.Fail("handler should not be invoked while shutting down")
+		return nil, nil
+	})
+	assert.Equal(codes.Unavailable, status.Code(err))
+
+	close(release)
+	assert.NoError(<-shutdownErr)
+}
+
+func Test_WhenShutdownIsRequested_ShouldRefuseNewStreams(t *testing.T) {
+	assert := assert2.New(t)
+
+	tracker := lifecycle.NewInFlightTracker()
+	interceptor := lifecyclegrpc.StreamServerInterceptor(tracker)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}
+
+	invoked := false
+	err := interceptor(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		invoked = true
+		return nil
+	})
+	assert.NoError(err)
+	assert.True(invoked)
+
+	tracker.StopAccepting()
+
+	err = interceptor(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		assert.Fail("handler should not be invoked while shutting down")
+		return nil
+	})
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
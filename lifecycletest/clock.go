@@ -0,0 +1,205 @@
+package lifecycletest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+)
+
+// Clock is a [lifecycle.Clock] whose time only passes when it is advanced
+type Clock struct {
+	mutex   *sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	tickers []*fakeTicker
+	// changed is closed, then replaced, whenever a timer is created or a ticker is started
+	changed chan struct{}
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewClock creates a new instance of [*Clock] set to the given time
+func NewClock(now time.Time) *Clock {
+	return &Clock{
+		mutex:   &sync.Mutex{},
+		now:     now,
+		changed: make(chan struct{}),
+	}
+}
+
+// Now returns the time of the clock
+func (clock *Clock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.now
+}
+
+// After returns a channel receiving the time of the clock once it is advanced by the duration
+func (clock *Clock) After(d time.Duration) <-chan time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	timer := &fakeTimer{
+		deadline: clock.now.Add(d),
+		c:        make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		timer.c <- clock.now
+		return timer.c
+	}
+
+	clock.timers = append(clock.timers, timer)
+
+	close(clock.changed)
+	clock.changed = make(chan struct{})
+
+	return timer.c
+}
+
+// NewTicker returns a [lifecycle.Ticker] delivering the time of the clock every period, as the clock is advanced
+func (clock *Clock) NewTicker(d time.Duration) lifecycle.Ticker {
+	ticker := &fakeTicker{
+		clock: clock,
+		c:     make(chan time.Time, 1),
+	}
+
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.tickers = append(clock.tickers, ticker)
+	ticker.start(d)
+
+	return ticker
+}
+
+// Advance moves the time of the clock forward, firing the timers and the tickers which are due
+func (clock *Clock) Advance(d time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(d)
+
+	pending := clock.timers[:0]
+	for _, timer := range clock.timers {
+		if timer.deadline.After(clock.now) {
+			pending = append(pending, timer)
+			continue
+		}
+
+		timer.c <- clock.now
+	}
+	clock.timers = pending
+
+	for _, ticker := range clock.tickers {
+		ticker.tick(clock.now)
+	}
+}
+
+// Timers returns the number of timers which did not fire yet, including the tickers which are running
+func (clock *Clock) Timers() int {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.pending()
+}
+
+// pending returns the number of timers which did not fire yet and of tickers which are running. Must be called while holding
+// the mutex.
+func (clock *Clock) pending() int {
+	count := len(clock.timers)
+	for _, ticker := range clock.tickers {
+		if ticker.running {
+			count++
+		}
+	}
+
+	return count
+}
+
+// TimersCreated returns a channel which is closed once at least n timers are waiting to fire, counting the tickers which are
+// running. It allows advancing the clock only once the code under test is waiting on it.
+func (clock *Clock) TimersCreated(n int) <-chan struct{} {
+	created := make(chan struct{})
+
+	go func() {
+		for {
+			clock.mutex.Lock()
+			changed := clock.changed
+			count := clock.pending()
+			clock.mutex.Unlock()
+
+			if count >= n {
+				close(created)
+				return
+			}
+
+			<-changed
+		}
+	}()
+
+	return created
+}
+
+type fakeTicker struct {
+	clock    *Clock
+	c        chan time.Time
+	period   time.Duration
+	deadline time.Time
+	running  bool
+}
+
+func (ticker *fakeTicker) C() <-chan time.Time {
+	return ticker.c
+}
+
+func (ticker *fakeTicker) Reset(d time.Duration) {
+	ticker.clock.mutex.Lock()
+	defer ticker.clock.mutex.Unlock()
+
+	select {
+	case <-ticker.c:
+	default:
+	}
+
+	ticker.start(d)
+}
+
+func (ticker *fakeTicker) Stop() {
+	ticker.clock.mutex.Lock()
+	defer ticker.clock.mutex.Unlock()
+
+	ticker.running = false
+}
+
+// start runs the ticker with the given period. Must be called while holding the clock's mutex.
+func (ticker *fakeTicker) start(d time.Duration) {
+	ticker.period = d
+	ticker.deadline = ticker.clock.now.Add(d)
+	ticker.running = true
+
+	close(ticker.clock.changed)
+	ticker.clock.changed = make(chan struct{})
+}
+
+// tick delivers the time if the ticker is due. Like a [time.Ticker], ticks are dropped when the previous one was not received
+// yet. Must be called while holding the clock's mutex.
+func (ticker *fakeTicker) tick(now time.Time) {
+	if !ticker.running || ticker.deadline.After(now) {
+		return
+	}
+
+	select {
+	case ticker.c <- now:
+	default:
+	}
+
+	for !ticker.deadline.After(now) {
+		ticker.deadline = ticker.deadline.Add(ticker.period)
+	}
+}
@@ -0,0 +1,209 @@
+// Package lifecycletest helps unit testing the lifecycle wiring of an application. Its [Harness] injects synthetic OS Signals
+// into [lifecycle.GracefulShutdown.WaitForShutdown], records which components completed their shutdown and in what order, and
+// controls the passing of time, so tests neither spawn processes nor sleep.
+package lifecycletest
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+)
+
+// DefaultWaitTimeout is the real time a [Harness] waits for the GracefulShutdown before failing the test
+var DefaultWaitTimeout = 5 * time.Second
+
+// Completion is a component which completed its shutdown
+type Completion struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Harness runs a [*lifecycle.GracefulShutdown] wired to synthetic signals and to a fake clock
+type Harness struct {
+	t testing.TB
+
+	// GracefulShutdown is the instance under test
+	GracefulShutdown *lifecycle.GracefulShutdown
+	// Signals relays the signals sent by the harness to WaitForShutdown
+	Signals *Signals
+	// Clock is the fake clock of the GracefulShutdown
+	Clock *Clock
+
+	mutex       *sync.Mutex
+	completions []Completion
+	result      chan error
+}
+
+// New creates a [*Harness]. The SignalNotifier and the Clock of the options are replaced by the harness' own.
+func New(t testing.TB, options lifecycle.GracefulShutdownOptions) *Harness {
+	h := &Harness{
+		t:       t,
+		Signals: NewSignals(),
+		Clock:   NewClock(time.Unix(0, 0)),
+		mutex:   &sync.Mutex{},
+	}
+
+	options.SignalNotifier = h.Signals
+	options.Clock = h.Clock
+
+	h.GracefulShutdown = lifecycle.NewGracefulShutdownWithOptions(context.Background(), options)
+	h.GracefulShutdown.AddListener(&recorder{harness: h})
+
+	return h
+}
+
+// Start runs WaitForShutdown in the background. Start returns once the GracefulShutdown listens for the given signal, or for
+// the first configured signal when none is given.
+func (h *Harness) Start(sig ...os.Signal) {
+	h.t.Helper()
+
+	if h.result != nil {
+		h.t.Fatal("lifecycletest: the harness was already started")
+		return
+	}
+
+	h.result = make(chan error, 1)
+	go func() {
+		h.result <- h.GracefulShutdown.WaitForShutdown()
+	}()
+
+	if len(sig) == 0 {
+		sig = lifecycle.DefaultSignals[:1]
+	}
+
+	for _, s := range sig {
+		select {
+		case <-h.Signals.Subscribed(s):
+		case <-time.After(DefaultWaitTimeout):
+			h.t.Fatalf("lifecycletest: WaitForShutdown did not listen for signal %s", s)
+			return
+		}
+	}
+}
+
+// Signal sends a synthetic signal to WaitForShutdown. It fails the test if no one listens for the signal.
+func (h *Harness) Signal(sig os.Signal) {
+	h.t.Helper()
+
+	if !h.Signals.Send(sig) {
+		h.t.Fatalf("lifecycletest: no one listens for signal %s", sig)
+	}
+}
+
+// Advance moves the fake clock forward. It first waits for the given number of timers to be pending, so the clock is only
+// advanced once the GracefulShutdown waits on it.
+func (h *Harness) Advance(d time.Duration, pendingTimers int) {
+	h.t.Helper()
+
+	select {
+	case <-h.Clock.TimersCreated(pendingTimers):
+	case <-time.After(DefaultWaitTimeout):
+		h.t.Fatalf("lifecycletest: %d timers were expected, but only %d were created", pendingTimers, h.Clock.Timers())
+		return
+	}
+
+	h.Clock.Advance(d)
+}
+
+// Wait returns the error returned by WaitForShutdown. It fails the test if WaitForShutdown does not return in time.
+func (h *Harness) Wait() error {
+	h.t.Helper()
+
+	if h.result == nil {
+		h.t.Fatal("lifecycletest: the harness was not started")
+		return nil
+	}
+
+	select {
+	case err := <-h.result:
+		h.result <- err
+		return err
+	case <-time.After(DefaultWaitTimeout):
+		h.t.Fatal("lifecycletest: WaitForShutdown did not return in time")
+		return nil
+	}
+}
+
+// Completions returns the components which completed their shutdown, in order of completion
+func (h *Harness) Completions() []Completion {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	completions := make([]Completion, len(h.completions))
+	copy(completions, h.completions)
+
+	return completions
+}
+
+// Completed returns the name of the components which completed their shutdown, in order of completion
+func (h *Harness) Completed() []string {
+	completions := h.Completions()
+
+	names := make([]string, len(completions))
+	for i, completion := range completions {
+		names[i] = completion.Name
+	}
+
+	return names
+}
+
+// AssertCompleted checks that exactly the given components completed their shutdown, in any order
+func (h *Harness) AssertCompleted(names ...string) bool {
+	h.t.Helper()
+
+	completed := make(map[string]int)
+	for _, name := range h.Completed() {
+		completed[name]++
+	}
+
+	expected := make(map[string]int)
+	for _, name := range names {
+		expected[name]++
+	}
+
+	if !reflect.DeepEqual(completed, expected) {
+		h.t.Errorf("lifecycletest: expected components %v to complete, got %v", names, h.Completed())
+		return false
+	}
+
+	return true
+}
+
+// AssertCompletedInOrder checks that exactly the given components completed their shutdown, in the given order
+func (h *Harness) AssertCompletedInOrder(names ...string) bool {
+	h.t.Helper()
+
+	completed := h.Completed()
+	if len(completed) != len(names) {
+		h.t.Errorf("lifecycletest: expected components %v to complete in order, got %v", names, completed)
+		return false
+	}
+
+	for i := range names {
+		if completed[i] != names[i] {
+			h.t.Errorf("lifecycletest: expected components %v to complete in order, got %v", names, completed)
+			return false
+		}
+	}
+
+	return true
+}
+
+// recorder records the completions of the components
+type recorder struct {
+	lifecycle.NoopListener
+	harness *Harness
+}
+
+func (r *recorder) ComponentShutdownCompleted(name string, err error, duration time.Duration) {
+	r.harness.mutex.Lock()
+	defer r.harness.mutex.Unlock()
+
+	r.harness.completions = append(r.harness.completions, Completion{Name: name, Err: err, Duration: duration})
+}
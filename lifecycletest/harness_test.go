@@ -0,0 +1,110 @@
+package lifecycletest_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenSignalIsSent_ShouldShutdownInPhaseOrder(t *testing.T) {
+	assert := assert2.New(t)
+	h := lifecycletest.New(t, lifecycle.GracefulShutdownOptions{})
+	gs := h.GracefulShutdown
+
+	assert.NoError(gs.RegisterComponentInPhase("http", 10, func() error { return nil }))
+	assert.NoError(gs.RegisterComponentInPhase("worker", 5, func() error { return nil }))
+	assert.NoError(gs.RegisterComponentInPhase("db", 0, func() error { return nil }))
+
+	h.Start()
+	h.Signal(syscall.SIGTERM)
+
+	assert.NoError(h.Wait())
+	assert.Equal(lifecycle.SignalError{Signal: syscall.SIGTERM}, gs.ShutdownReason())
+	h.AssertCompletedInOrder("http", "worker", "db")
+}
+
+func Test_WhenClockIsAdvancedPastTimeout_ShouldReportStragglers(t *testing.T) {
+	assert := assert2.New(t)
+	h := lifecycletest.New(t, lifecycle.GracefulShutdownOptions{
+		Timeout: time.Minute,
+	})
+	gs := h.GracefulShutdown
+
+	release := make(chan struct{})
+	defer close(release)
+
+	assert.NoError(gs.RegisterComponentWithFn("stuck", func() error {
+		<-release
+		return nil
+	}))
+	assert.NoError(gs.RegisterComponentWithFn("quick", func() error { return nil }))
+
+	h.Start()
+	h.Signal(syscall.SIGINT)
+
+	assert.Eventually(func() bool {
+		return len(h.Completed()) == 1
+	}, time.Second, time.Millisecond, "quick component should complete before the timeout")
+	h.Advance(time.Minute, 1)
+
+	err := h.Wait()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.True(errors.Is(shutdownErr.ComponentErrors["stuck"], lifecycle.ErrShutdownTimeout))
+	}
+	h.AssertCompletedInOrder("quick", "stuck")
+	assert.Equal(time.Minute, h.Completions()[1].Duration)
+}
+
+func Test_WhenPreShutdownDelayIsConfigured_ShouldWaitForClock(t *testing.T) {
+	assert := assert2.New(t)
+	h := lifecycletest.New(t, lifecycle.GracefulShutdownOptions{
+		PreShutdownDelay: 10 * time.Second,
+	})
+	gs := h.GracefulShutdown
+
+	assert.NoError(gs.RegisterComponentWithFn("component", func() error { return nil }))
+
+	h.Start()
+	h.Signal(syscall.SIGTERM)
+
+	<-gs.Draining()
+	assert.NoError(gs.AppContext().Err(), "AppContext should not be cancelled before the delay elapses")
+
+	h.Advance(10*time.Second, 1)
+
+	assert.NoError(h.Wait())
+	h.AssertCompleted("component")
+}
+
+func Test_WhenSignalIsHandled_ShouldNotShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	h := lifecycletest.New(t, lifecycle.GracefulShutdownOptions{})
+	gs := h.GracefulShutdown
+
+	handled := make(chan struct{})
+	gs.HandleSignal(syscall.SIGHUP, func(ctx context.Context) error {
+		close(handled)
+		return nil
+	})
+
+	h.Start(syscall.SIGHUP)
+	h.Signal(syscall.SIGHUP)
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		assert.Fail("handler should have been invoked")
+	}
+	assert.NoError(gs.AppContext().Err())
+
+	h.Signal(syscall.SIGTERM)
+	assert.NoError(h.Wait())
+}
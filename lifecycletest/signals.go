@@ -0,0 +1,111 @@
+package lifecycletest
+
+import (
+	"os"
+	"sync"
+)
+
+// Signals is a [lifecycle.SignalNotifier] relaying synthetic signals instead of the ones received by the process
+type Signals struct {
+	mutex         *sync.Mutex
+	subscriptions map[chan<- os.Signal][]os.Signal
+	// changed is closed, then replaced, whenever the subscriptions change
+	changed chan struct{}
+}
+
+// NewSignals creates a new instance of [*Signals]
+func NewSignals() *Signals {
+	return &Signals{
+		mutex:         &sync.Mutex{},
+		subscriptions: make(map[chan<- os.Signal][]os.Signal),
+		changed:       make(chan struct{}),
+	}
+}
+
+// Notify relays the given signals to the channel
+func (signals *Signals) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signals.mutex.Lock()
+	defer signals.mutex.Unlock()
+
+	signals.subscriptions[c] = append(signals.subscriptions[c], sig...)
+	signals.notifyChange()
+}
+
+// Stop stops relaying any signal to the channel
+func (signals *Signals) Stop(c chan<- os.Signal) {
+	signals.mutex.Lock()
+	defer signals.mutex.Unlock()
+
+	delete(signals.subscriptions, c)
+	signals.notifyChange()
+}
+
+// Send relays the signal to the channels subscribed to it. Like the os/signal package, it does not block on a full channel.
+// It returns false if no channel is subscribed to the signal.
+func (signals *Signals) Send(sig os.Signal) bool {
+	signals.mutex.Lock()
+	defer signals.mutex.Unlock()
+
+	sent := false
+	for c, subscribed := range signals.subscriptions {
+		if !contains(subscribed, sig) {
+			continue
+		}
+
+		select {
+		case c <- sig:
+		default:
+		}
+		sent = true
+	}
+
+	return sent
+}
+
+// Subscribed returns a channel which is closed once a channel is subscribed to the signal
+func (signals *Signals) Subscribed(sig os.Signal) <-chan struct{} {
+	subscribed := make(chan struct{})
+
+	go func() {
+		for {
+			signals.mutex.Lock()
+			changed := signals.changed
+			found := signals.isSubscribed(sig)
+			signals.mutex.Unlock()
+
+			if found {
+				close(subscribed)
+				return
+			}
+
+			<-changed
+		}
+	}()
+
+	return subscribed
+}
+
+func (signals *Signals) isSubscribed(sig os.Signal) bool {
+	for _, subscribed := range signals.subscriptions {
+		if contains(subscribed, sig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (signals *Signals) notifyChange() {
+	close(signals.changed)
+	signals.changed = make(chan struct{})
+}
+
+func contains(signals []os.Signal, sig os.Signal) bool {
+	for _, candidate := range signals {
+		if candidate == sig {
+			return true
+		}
+	}
+
+	return false
+}
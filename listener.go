@@ -0,0 +1,33 @@
+package lifecycle
+
+import "time"
+
+// Listener observes the lifecycle of a [GracefulShutdown]. Embed [NoopListener] to only implement the callbacks you need.
+//
+// Callbacks are invoked synchronously, and should therefore return quickly.
+type Listener interface {
+	// ComponentRegistered is called when a component is successfully registered
+	ComponentRegistered(name string)
+	// ShutdownRequested is called once, when the shutdown is requested, with the reason of the shutdown
+	ShutdownRequested(reason error)
+	// ComponentShutdownCompleted is called when a component is done shutting down, or when it is considered as timed out.
+	// The duration is measured from the moment the component was signaled.
+	ComponentShutdownCompleted(name string, err error, duration time.Duration)
+	// ShutdownFinished is called once all components are done shutting down, with the error returned by the shutdown
+	ShutdownFinished(err error)
+}
+
+// NoopListener is a [Listener] which does nothing. It is meant to be embedded in partial Listener implementations.
+type NoopListener struct{}
+
+// ComponentRegistered does nothing
+func (NoopListener) ComponentRegistered(name string) {}
+
+// ShutdownRequested does nothing
+func (NoopListener) ShutdownRequested(reason error) {}
+
+// ComponentShutdownCompleted does nothing
+func (NoopListener) ComponentShutdownCompleted(name string, err error, duration time.Duration) {}
+
+// ShutdownFinished does nothing
+func (NoopListener) ShutdownFinished(err error) {}
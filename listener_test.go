@@ -0,0 +1,76 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type recordingListener struct {
+	lifecycle.NoopListener
+
+	mutex     sync.Mutex
+	events    []string
+	durations map[string]time.Duration
+}
+
+func (l *recordingListener) record(event string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *recordingListener) ComponentRegistered(name string) {
+	l.record("registered:" + name)
+}
+
+func (l *recordingListener) ShutdownRequested(reason error) {
+	l.record("requested")
+}
+
+func (l *recordingListener) ComponentShutdownCompleted(name string, err error, duration time.Duration) {
+	l.mutex.Lock()
+	l.durations[name] = duration
+	l.mutex.Unlock()
+
+	if err != nil {
+		l.record("failed:" + name)
+		return
+	}
+
+	l.record("completed:" + name)
+}
+
+func (l *recordingListener) ShutdownFinished(err error) {
+	l.record("finished")
+}
+
+func Test_WhenListenerIsAdded_ShouldBeNotifiedOfLifecycleEvents(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	listener := &recordingListener{durations: make(map[string]time.Duration)}
+	gs.AddListener(listener)
+
+	CreateSuccessComponent(gs, "ComponentA", 100*time.Millisecond)
+	CreateErrorComponent(gs, "ComponentB", errors.New("test error"))
+
+	_ = gs.Shutdown()
+
+	assert.Equal([]string{
+		"registered:ComponentA",
+		"registered:ComponentB",
+		"requested",
+		"failed:ComponentB",
+		"completed:ComponentA",
+		"finished",
+	}, listener.events)
+
+	assert.GreaterOrEqual(listener.durations["ComponentA"], 100*time.Millisecond)
+}
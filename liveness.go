@@ -0,0 +1,70 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LivenessReport is the JSON document served by [ReadyCheck.LivenessHandler]
+type LivenessReport struct {
+	// Live is true if all the liveness checks are ready
+	Live bool `json:"live"`
+	// Draining is true while the application is being shutdown. It does not affect the liveness.
+	Draining bool `json:"draining"`
+}
+
+// Live returns true if all the poll and pulse checks are ready, since their failure means the process is stuck. Push checks,
+// non-critical checks and the checks excluded with [ExcludeFromLiveness] do not affect the liveness.
+//
+// The liveness is frozen when the drain mode starts: while the application is being shutdown, its workers stop and their pulses
+// expire, yet it stays live even though it is no longer ready, so the orchestrator does not kill it in the middle of its drain.
+func (rdy *ReadyCheck) Live() bool {
+	if rdy.draining.Load() {
+		return rdy.drainLive.Load()
+	}
+
+	return rdy.live()
+}
+
+func (rdy *ReadyCheck) live() bool {
+	rdy.componentsMutex.RLock()
+	defer rdy.componentsMutex.RUnlock()
+
+	for _, component := range rdy.components {
+		switch component.(type) {
+		case *PollComponentCheck, *PulseComponentCheck:
+			settings := rdy.settings[component.Name()]
+			if settings.nonCritical || settings.excludeLiveness {
+				continue
+			}
+
+			if !component.Ready() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// LivenessHandler returns an [http.Handler] reporting the liveness of the application, typically served as a Kubernetes
+// liveness probe. It responds with a 200 OK status while [ReadyCheck.Live] is true, including while the application is
+// draining, and a 503 Service Unavailable status otherwise. The body is a JSON [LivenessReport].
+func (rdy *ReadyCheck) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := LivenessReport{
+			Live:     rdy.Live(),
+			Draining: rdy.Draining(),
+		}
+
+		status := http.StatusOK
+		if !report.Live {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,124 @@
+package lifecycle_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func serveLiveness(rdy *lifecycle.ReadyCheck) (int, lifecycle.LivenessReport) {
+	recorder := httptest.NewRecorder()
+	rdy.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/live", nil))
+
+	report := lifecycle.LivenessReport{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &report)
+
+	return recorder.Code, report
+}
+
+func Test_WhenApplicationIsDraining_ShouldStayLiveButNotReady(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		PreShutdownDelay: 100 * time.Millisecond,
+	})
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPulseComponent("main-loop", time.Minute).RecordPulse()
+	gs.AttachReadyCheck(rdy)
+
+	go func() {
+		_ = gs.Shutdown()
+	}()
+	<-gs.Draining()
+	assert.Eventually(rdy.Draining, time.Second, time.Millisecond)
+
+	readinessStatus, _ := serveReadiness(rdy)
+	assert.Equal(http.StatusServiceUnavailable, readinessStatus)
+
+	livenessStatus, report := serveLiveness(rdy)
+	assert.Equal(http.StatusOK, livenessStatus)
+	assert.True(report.Live)
+	assert.True(report.Draining)
+}
+
+func Test_WhenWrappedLoopExitsDuringDrain_ShouldStayLive(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	rdy := lifecycle.NewReadyCheck()
+	gs.AttachReadyCheck(rdy)
+
+	loopExited := make(chan struct{})
+	pulse := rdy.RegisterPulseComponent("main-loop", 20*time.Millisecond)
+	go func() {
+		defer close(loopExited)
+
+		pulse.Wrap(func(beat func()) {
+			for {
+				beat()
+
+				select {
+				case <-gs.AppContext().Done():
+					return
+				case <-time.After(5 * time.Millisecond):
+				}
+			}
+		})
+	}()
+	assert.Eventually(rdy.Live, time.Second, time.Millisecond)
+
+	release := make(chan struct{})
+	_ = gs.RegisterComponentWithFn("server", func() error {
+		<-release
+		return nil
+	})
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- gs.Shutdown()
+	}()
+
+	<-loopExited
+	time.Sleep(30 * time.Millisecond)
+
+	livenessStatus, report := serveLiveness(rdy)
+	assert.Equal(http.StatusOK, livenessStatus, "liveness should not fail in the middle of the drain")
+	assert.True(report.Live)
+	assert.True(report.Draining)
+
+	close(release)
+	assert.NoError(<-shutdownDone)
+}
+
+func Test_WhenPulseExpires_ShouldNotBeLive(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPulseComponent("main-loop", time.Millisecond).RecordPulse()
+	rdy.RegisterPushComponent("cache").SetReady(false)
+
+	assert.Eventually(func() bool {
+		return !rdy.Live()
+	}, time.Second, time.Millisecond)
+
+	status, report := serveLiveness(rdy)
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.False(report.Live)
+}
+
+func Test_WhenCheckIsExcludedFromLiveness_ShouldStayLive(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPollComponent("db", func() bool { return false }, time.Minute, lifecycle.ExcludeFromLiveness())
+	rdy.RegisterPushComponent("cache").SetReady(false)
+
+	assert.True(rdy.Live())
+	assert.False(rdy.Ready())
+}
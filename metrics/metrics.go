@@ -0,0 +1,201 @@
+// Package metrics exposes the state of the lifecycle utilities as Prometheus metrics.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CollectorOptions are options used in conjunction with the [Collector] type
+type CollectorOptions struct {
+	// Namespace is the prefix of the metric names
+	//
+	// Default: lifecycle
+	Namespace string
+	// ReadyCheck is the ready check whose components readiness is exported. Readiness metrics are omitted when nil.
+	ReadyCheck *lifecycle.ReadyCheck
+	// GracefulShutdown is the graceful shutdown whose components shutdown duration is exported. Shutdown metrics are omitted
+	// when nil.
+	GracefulShutdown *lifecycle.GracefulShutdown
+	// ShutdownBuckets are the buckets of the shutdown duration histogram, in seconds
+	//
+	// Default: 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30
+	ShutdownBuckets []float64
+}
+
+var (
+	DefaultNamespace       = "lifecycle"
+	DefaultShutdownBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
+// Collector is a [prometheus.Collector] exporting the following metrics:
+//   - <namespace>_ready: 1 if the application is ready, 0 otherwise;
+//   - <namespace>_ready_flaps_total: number of times the application readiness changed;
+//   - <namespace>_component_ready: 1 if the component is ready, 0 otherwise;
+//   - <namespace>_component_ready_flaps_total: number of times the component readiness changed;
+//   - <namespace>_component_check_latency_seconds: time taken by the last poll of each poll component;
+//   - <namespace>_component_shutdown_duration_seconds: histogram of the time each component took to shutdown.
+type Collector struct {
+	lifecycle.NoopListener
+
+	readyCheck *lifecycle.ReadyCheck
+
+	readyDesc          *prometheus.Desc
+	readyFlaps         prometheus.Counter
+	componentReadyDesc *prometheus.Desc
+	checkLatencyDesc   *prometheus.Desc
+	flaps              *prometheus.CounterVec
+	shutdownDuration   *prometheus.HistogramVec
+
+	flapsMutex    *sync.Mutex
+	flappingNames map[string]bool
+}
+
+// NewCollector creates a new [Collector]. When a ReadyCheck is provided, the collector counts its transitions with
+// [lifecycle.ReadyCheck.OnChange] and [lifecycle.ReadyCheck.OnReadyChange], so that no flap is missed between collections, and
+// exports the readiness observed with [lifecycle.ReadyCheck.ObservedReadiness]. When a GracefulShutdown is provided, the
+// collector registers itself as one of its listeners. The collector must then be registered in a [prometheus.Registerer].
+func NewCollector(options CollectorOptions) *Collector {
+	if options.Namespace == "" {
+		options.Namespace = DefaultNamespace
+	}
+
+	if len(options.ShutdownBuckets) == 0 {
+		options.ShutdownBuckets = DefaultShutdownBuckets
+	}
+
+	collector := &Collector{
+		readyCheck: options.ReadyCheck,
+
+		readyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(options.Namespace, "", "ready"),
+			"Whether the application is ready (1) or not (0)",
+			nil,
+			nil,
+		),
+		readyFlaps: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Name:      "ready_flaps_total",
+			Help:      "Number of times the application readiness changed",
+		}),
+		componentReadyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(options.Namespace, "component", "ready"),
+			"Whether the component is ready (1) or not (0)",
+			[]string{"component"},
+			nil,
+		),
+		checkLatencyDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(options.Namespace, "component", "check_latency_seconds"),
+			"Time taken by the last poll of the component",
+			[]string{"component"},
+			nil,
+		),
+		flaps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: options.Namespace,
+			Subsystem: "component",
+			Name:      "ready_flaps_total",
+			Help:      "Number of times the component readiness changed",
+		}, []string{"component"}),
+		shutdownDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: options.Namespace,
+			Subsystem: "component",
+			Name:      "shutdown_duration_seconds",
+			Help:      "Time taken by the component to shutdown",
+			Buckets:   options.ShutdownBuckets,
+		}, []string{"component"}),
+
+		flapsMutex:    &sync.Mutex{},
+		flappingNames: make(map[string]bool),
+	}
+
+	if options.ReadyCheck != nil {
+		options.ReadyCheck.OnChange(collector.componentChanged)
+		options.ReadyCheck.OnReadyChange(collector.readyChanged)
+	}
+
+	if options.GracefulShutdown != nil {
+		options.GracefulShutdown.AddListener(collector)
+	}
+
+	return collector
+}
+
+// Describe implements [prometheus.Collector]
+func (collector *Collector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.readyDesc
+	collector.readyFlaps.Describe(descs)
+	descs <- collector.componentReadyDesc
+	descs <- collector.checkLatencyDesc
+	collector.flaps.Describe(descs)
+	collector.shutdownDuration.Describe(descs)
+}
+
+// Collect implements [prometheus.Collector]
+func (collector *Collector) Collect(metrics chan<- prometheus.Metric) {
+	if collector.readyCheck != nil {
+		collector.collectReadiness(metrics)
+	}
+
+	collector.readyFlaps.Collect(metrics)
+	collector.flaps.Collect(metrics)
+	collector.shutdownDuration.Collect(metrics)
+}
+
+// collectReadiness exports the readiness observed by the ReadyCheck when detecting its transitions, so that scraping neither
+// evaluates the checks nor interferes with the hold-down
+func (collector *Collector) collectReadiness(metrics chan<- prometheus.Metric) {
+	isAppReady, components := collector.readyCheck.ObservedReadiness()
+
+	metrics <- prometheus.MustNewConstMetric(collector.readyDesc, prometheus.GaugeValue, gaugeValue(isAppReady))
+
+	for component, isReady := range components {
+		metrics <- prometheus.MustNewConstMetric(collector.componentReadyDesc, prometheus.GaugeValue, gaugeValue(isReady), component)
+	}
+
+	collector.forgetUnregistered(components)
+
+	for component, latency := range collector.readyCheck.CheckLatencies() {
+		metrics <- prometheus.MustNewConstMetric(collector.checkLatencyDesc, prometheus.GaugeValue, latency.Seconds(), component)
+	}
+}
+
+func (collector *Collector) componentChanged(component string, ready bool) {
+	collector.flapsMutex.Lock()
+	defer collector.flapsMutex.Unlock()
+
+	collector.flappingNames[component] = true
+	collector.flaps.WithLabelValues(component).Inc()
+}
+
+func (collector *Collector) readyChanged(ready bool) {
+	collector.readyFlaps.Inc()
+}
+
+// forgetUnregistered removes the flaps of the components which are no longer registered
+func (collector *Collector) forgetUnregistered(components map[string]bool) {
+	collector.flapsMutex.Lock()
+	defer collector.flapsMutex.Unlock()
+
+	for component := range collector.flappingNames {
+		if _, ok := components[component]; !ok {
+			collector.flaps.DeleteLabelValues(component)
+			delete(collector.flappingNames, component)
+		}
+	}
+}
+
+func gaugeValue(isReady bool) float64 {
+	if isReady {
+		return 1
+	}
+
+	return 0
+}
+
+// ComponentShutdownCompleted implements [lifecycle.Listener] by recording the component's shutdown duration
+func (collector *Collector) ComponentShutdownCompleted(name string, err error, duration time.Duration) {
+	collector.shutdownDuration.WithLabelValues(name).Observe(duration.Seconds())
+}
@@ -0,0 +1,199 @@
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenCollectingReadiness_ShouldExportGaugesAndFlaps(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	push := readyCheck.RegisterPushComponent("db")
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(registry.Register(collector))
+
+	assert.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP lifecycle_component_ready Whether the component is ready (1) or not (0)
+# TYPE lifecycle_component_ready gauge
+lifecycle_component_ready{component="db"} 0
+`), "lifecycle_component_ready"))
+
+	push.SetReady(true)
+
+	assert.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP lifecycle_component_ready Whether the component is ready (1) or not (0)
+# TYPE lifecycle_component_ready gauge
+lifecycle_component_ready{component="db"} 1
+# HELP lifecycle_component_ready_flaps_total Number of times the component readiness changed
+# TYPE lifecycle_component_ready_flaps_total counter
+lifecycle_component_ready_flaps_total{component="db"} 1
+`), "lifecycle_component_ready", "lifecycle_component_ready_flaps_total"))
+}
+
+func Test_WhenCollectingReadiness_ShouldExportApplicationReadiness(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	readyCheck.RegisterPushComponent("db").SetReady(true)
+	cache := readyCheck.RegisterPushComponent("cache")
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(registry.Register(collector))
+
+	assert.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP lifecycle_ready Whether the application is ready (1) or not (0)
+# TYPE lifecycle_ready gauge
+lifecycle_ready 0
+# HELP lifecycle_ready_flaps_total Number of times the application readiness changed
+# TYPE lifecycle_ready_flaps_total counter
+lifecycle_ready_flaps_total 0
+`), "lifecycle_ready", "lifecycle_ready_flaps_total"))
+
+	cache.SetReady(true)
+
+	assert.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP lifecycle_ready Whether the application is ready (1) or not (0)
+# TYPE lifecycle_ready gauge
+lifecycle_ready 1
+# HELP lifecycle_ready_flaps_total Number of times the application readiness changed
+# TYPE lifecycle_ready_flaps_total counter
+lifecycle_ready_flaps_total 1
+`), "lifecycle_ready", "lifecycle_ready_flaps_total"))
+}
+
+func Test_WhenReadinessFlapsBetweenCollections_ShouldCountEachFlap(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	push := readyCheck.RegisterPushComponent("db")
+	readyCheck.RegisterPushComponent("cache")
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(registry.Register(collector))
+
+	push.SetReady(true)
+	push.SetReady(false)
+
+	assert.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP lifecycle_component_ready_flaps_total Number of times the component readiness changed
+# TYPE lifecycle_component_ready_flaps_total counter
+lifecycle_component_ready_flaps_total{component="db"} 2
+`), "lifecycle_component_ready_flaps_total"))
+
+	readyCheck.UnregisterComponent("db")
+
+	assert.Equal(0, testutil.CollectAndCount(collector, "lifecycle_component_ready_flaps_total"),
+		"flaps of unregistered components should be removed")
+}
+
+type countingCheck struct {
+	calls *atomic.Int32
+}
+
+func (check countingCheck) Name() string {
+	return "remote"
+}
+
+func (check countingCheck) Ready() bool {
+	check.calls.Add(1)
+	return true
+}
+
+func Test_WhenCollectingReadiness_ShouldNotEvaluateChecks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	calls := &atomic.Int32{}
+	readyCheck.RegisterComponent("remote", countingCheck{calls: calls})
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	// The check is evaluated in the background once the collector observes the transitions
+	assert.Eventually(func() bool {
+		return calls.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(3, testutil.CollectAndCount(collector))
+	}
+	assert.Equal(int32(1), calls.Load(), "scrapes should not evaluate the checks")
+}
+
+func Test_WhenPulsesAreRecorded_ShouldNotEvaluateChecks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	calls := &atomic.Int32{}
+	readyCheck.RegisterComponent("remote", countingCheck{calls: calls})
+	pulse := readyCheck.RegisterPulseComponent("worker", time.Minute)
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	for i := 0; i < 2000; i++ {
+		pulse.RecordPulse()
+		testutil.CollectAndCount(collector)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(calls.Load(), int32(2), "the collector should not cause the checks to be evaluated on each pulse")
+}
+
+func Test_WhenCollectingReadiness_ShouldExportPollLatency(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	readyCheck.RegisterPollComponentWithOptions("db", func() bool {
+		return true
+	}, lifecycle.PollOptions{Interval: time.Hour, ImmediateCheck: true})
+	readyCheck.RegisterPushComponent("cache")
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		ReadyCheck: readyCheck,
+	})
+
+	assert.Equal(1, testutil.CollectAndCount(collector, "lifecycle_component_check_latency_seconds"))
+}
+
+func Test_WhenShutdownCompletes_ShouldObserveDurations(t *testing.T) {
+	assert := assert2.New(t)
+
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	_ = gs.RegisterComponentWithFn("http-server", func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	collector := metrics.NewCollector(metrics.CollectorOptions{
+		GracefulShutdown: gs,
+	})
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal(1, testutil.CollectAndCount(collector, "lifecycle_component_shutdown_duration_seconds"))
+}
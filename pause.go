@@ -0,0 +1,119 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+)
+
+// pauseState tracks whether the background work of the application is paused
+type pauseState struct {
+	mutex *sync.Mutex
+
+	paused  bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	resumed chan struct{}
+}
+
+func newPauseState(appCtx context.Context) *pauseState {
+	ctx, cancel := context.WithCancel(appCtx)
+
+	return &pauseState{
+		mutex:  &sync.Mutex{},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Pause asks the components to halt their background work, while the process stays alive and its liveness probe healthy. This
+// is useful for blue/green cutovers and debugging. Components observe it through [GracefulShutdown.PauseContext] or
+// [GracefulShutdown.WaitWhilePaused]. Pausing an application which is already paused does nothing.
+//
+// Once the shutdown was requested, a [ErrAlreadyShutdown] error is returned.
+func (gs *GracefulShutdown) Pause() error {
+	select {
+	case <-gs.Draining():
+		return ErrAlreadyShutdown
+	default:
+	}
+
+	state := gs.pauseState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if state.paused {
+		return nil
+	}
+
+	state.paused = true
+	state.resumed = make(chan struct{})
+	state.cancel()
+
+	gs.recordEvent(Event{Type: EventPaused})
+
+	return nil
+}
+
+// Resume lets the components resume their background work. Resuming an application which is not paused does nothing.
+func (gs *GracefulShutdown) Resume() {
+	state := gs.pauseState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if !state.paused {
+		return
+	}
+
+	state.paused = false
+	state.ctx, state.cancel = context.WithCancel(gs.AppContext())
+	close(state.resumed)
+
+	gs.recordEvent(Event{Type: EventResumed})
+}
+
+// Paused returns true while the application is paused
+func (gs *GracefulShutdown) Paused() bool {
+	state := gs.pauseState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.paused
+}
+
+// PauseContext returns a context which is done as soon as the application is paused or shutdown. Once resumed, a new context
+// must be retrieved.
+func (gs *GracefulShutdown) PauseContext() context.Context {
+	state := gs.pauseState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.ctx
+}
+
+// WaitWhilePaused blocks while the application is paused. It returns immediately when the application is not paused, and
+// returns the context's error if the context is done first.
+func (gs *GracefulShutdown) WaitWhilePaused(ctx context.Context) error {
+	state := gs.pauseState()
+	state.mutex.Lock()
+	paused := state.paused
+	resumed := state.resumed
+	state.mutex.Unlock()
+
+	if !paused {
+		return nil
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (gs *GracefulShutdown) pauseState() *pauseState {
+	gs.componentMutex.RLock()
+	defer gs.componentMutex.RUnlock()
+
+	return gs.pause
+}
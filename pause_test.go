@@ -0,0 +1,71 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenPaused_ShouldHaltBackgroundWorkUntilResumed(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	processed := atomic.Int32{}
+	go func() {
+		for gs.WaitWhilePaused(gs.AppContext()) == nil {
+			ctx := gs.PauseContext()
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Millisecond):
+				processed.Add(1)
+			}
+		}
+	}()
+
+	assert.Eventually(func() bool { return processed.Load() > 0 }, time.Second, time.Millisecond)
+
+	pauseCtx := gs.PauseContext()
+	assert.NoError(gs.Pause())
+	assert.True(gs.Paused())
+	assert.Error(pauseCtx.Err(), "pause context should be cancelled")
+	assert.NoError(gs.AppContext().Err(), "application should stay alive")
+
+	time.Sleep(10 * time.Millisecond)
+	halted := processed.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(halted, processed.Load(), "background work should be halted")
+
+	gs.Resume()
+	assert.False(gs.Paused())
+	assert.NoError(gs.PauseContext().Err())
+	assert.Eventually(func() bool { return processed.Load() > halted }, time.Second, time.Millisecond)
+
+	assert.NoError(gs.Shutdown())
+}
+
+func Test_WhenPausedApplicationIsShutdown_ShouldReleaseWaiters(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.NoError(gs.Pause())
+
+	waited := make(chan error)
+	go func() {
+		waited <- gs.WaitWhilePaused(gs.AppContext())
+	}()
+
+	assert.NoError(gs.Shutdown())
+
+	select {
+	case err := <-waited:
+		assert.ErrorIs(err, context.Canceled)
+	case <-time.After(time.Second):
+		assert.Fail("waiters should be released by the shutdown")
+	}
+
+	assert.ErrorIs(gs.Pause(), lifecycle.ErrAlreadyShutdown)
+}
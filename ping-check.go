@@ -0,0 +1,49 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+)
+
+var DefaultPingTimeout = 1 * time.Second
+
+// Pinger is implemented by the clients able to verify their connection, such as [*sql.DB]
+//
+// [*sql.DB]: https://pkg.go.dev/database/sql#DB.PingContext
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingOptions are the options of a ping check
+type PingOptions struct {
+	// Poll configures how often the pinger is pinged, and how many pings are needed to change the readiness
+	Poll PollOptions
+	// Timeout is the time given to each ping. Defaults to [DefaultPingTimeout].
+	Timeout time.Duration
+}
+
+// PingCheck creates a [PollComponentCheck] pinging the given [Pinger] in the background, with the default [PingOptions]. The
+// pings start once the check is registered and the ReadyCheck is started.
+func PingCheck(name string, pinger Pinger) *PollComponentCheck {
+	return PingCheckWithOptions(name, pinger, PingOptions{})
+}
+
+// PingCheckWithOptions creates a [PollComponentCheck] pinging the given [Pinger] in the background. Each ping must complete
+// within the [PingOptions.Timeout]. While the component is not ready, the error of the last failed ping is reported in its
+// [CheckResult]. The component is reported as a [HealthComponentTypeDatastore] by [ReadyCheck.HealthDocument].
+func PingCheckWithOptions(name string, pinger Pinger, options PingOptions) *PollComponentCheck {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultPingTimeout
+	}
+
+	component := newPollComponentCheck(name, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+		defer cancel()
+
+		err := pinger.PingContext(ctx)
+		return err == nil, err
+	}, options.Poll, SystemClock)
+	component.componentType = HealthComponentTypeDatastore
+
+	return component
+}
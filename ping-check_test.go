@@ -0,0 +1,104 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakePinger struct {
+	err   error
+	pings *atomic.Int32
+}
+
+func (p fakePinger) PingContext(ctx context.Context) error {
+	if p.pings != nil {
+		p.pings.Add(1)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("ping should have a deadline")
+	}
+
+	return p.err
+}
+
+type blockingPinger struct{}
+
+func (blockingPinger) PingContext(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func Test_WhenPingSucceeds_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("db", lifecycle.PingCheckWithOptions("db", fakePinger{}, lifecycle.PingOptions{
+		Poll: lifecycle.PollOptions{ImmediateCheck: true},
+	}))
+
+	assert.True(readycheck.Ready())
+}
+
+func Test_WhenPingFails_ShouldReportError(t *testing.T) {
+	assert := assert2.New(t)
+
+	errUnreachable := errors.New("unreachable")
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("db", lifecycle.PingCheckWithOptions("db", fakePinger{err: errUnreachable}, lifecycle.PingOptions{
+		Poll: lifecycle.PollOptions{ImmediateCheck: true},
+	}))
+
+	assert.False(readycheck.Ready())
+	assert.ErrorIs(readycheck.ExplainDetailed()["db"].Err, errUnreachable)
+}
+
+func Test_WhenPingTakesTooLong_ShouldReportTimeout(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("db", lifecycle.PingCheckWithOptions("db", blockingPinger{}, lifecycle.PingOptions{
+		Poll:    lifecycle.PollOptions{ImmediateCheck: true},
+		Timeout: 10 * time.Millisecond,
+	}))
+
+	assert.False(readycheck.Ready())
+	assert.ErrorIs(readycheck.ExplainDetailed()["db"].Err, context.DeadlineExceeded)
+}
+
+func Test_WhenPingCheckIsChecked_ShouldNotPingAgain(t *testing.T) {
+	assert := assert2.New(t)
+
+	pings := &atomic.Int32{}
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("db", lifecycle.PingCheckWithOptions("db", fakePinger{pings: pings}, lifecycle.PingOptions{
+		Poll: lifecycle.PollOptions{ImmediateCheck: true, Interval: time.Hour},
+	}))
+
+	assert.True(readycheck.Ready())
+	assert.True(readycheck.Ready())
+	assert.True(readycheck.ExplainDetailed()["db"].Ready)
+	assert.Equal(int32(1), pings.Load())
+}
+
+func Test_WhenPingCheckIsNestedInComposite_ShouldBePolled(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("dbs", lifecycle.All("dbs",
+		lifecycle.PingCheckWithOptions("primary", fakePinger{}, lifecycle.PingOptions{
+			Poll: lifecycle.PollOptions{Interval: 10 * time.Millisecond},
+		}),
+	))
+
+	readycheck.StartPolling()
+	defer readycheck.StopPolling()
+
+	assert.Eventually(readycheck.Ready, time.Second, 10*time.Millisecond)
+}
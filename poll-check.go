@@ -1,19 +1,125 @@
 package lifecycle
 
 import (
+	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
+var DefaultPollInterval = 1 * time.Second
+
+// PollOptions are options used in conjunction with the [PollComponentCheck] type
+type PollOptions struct {
+	// Interval is the time waited between two polls
+	//
+	// Default: 1s
+	Interval time.Duration
+	// FailureThreshold is the number of consecutive failed polls required to mark a ready component as not ready
+	//
+	// Default: 1
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful polls required to mark a component as ready
+	//
+	// Default: 1
+	SuccessThreshold int
+	// BackoffBase is the delay waited after the first failed poll when the backoff is enabled. The delay doubles with each
+	// consecutive failure, up to BackoffMax, and the Interval is used again after the first successful poll.
+	//
+	// Default: Interval
+	BackoffBase time.Duration
+	// BackoffMax is the maximum delay waited between two failed polls. The backoff is disabled when zero.
+	//
+	// Default: 0
+	BackoffMax time.Duration
+	// ImmediateCheck runs the check synchronously when the component is registered, so that [ReadyCheck.Ready] reflects its
+	// readiness right away instead of reporting it as not ready until the first poll completes
+	//
+	// Default: false
+	ImmediateCheck bool
+	// LatencyThreshold is the time a poll may take before the component is reported as [StatusDegraded], since a slow check
+	// is a sign of a struggling dependency. It is disabled when zero.
+	//
+	// Default: 0
+	LatencyThreshold time.Duration
+}
+
 // PollComponentCheck is a component check where the reporting mechanism will be polled
 // every X amount of time.
 type PollComponentCheck struct {
-	name     string
-	isReady  *atomic.Bool
-	isActive *atomic.Bool
+	changeNotifier
+	historyRecorder
+
+	name      string
+	isReady   *atomic.Bool
+	checkedAt *atomic.Pointer[time.Time]
+	latency   *atomic.Int64
+
+	runMutex *sync.Mutex
+	running  context.Context
+	cancel   context.CancelFunc
+	clock    Clock
+
+	options PollOptions
+	checkFn func() (bool, error)
+	lastErr *atomic.Pointer[error]
+
+	// componentType is the type reported by the health document, when the check knows what it polls
+	componentType string
+
+	// Serializes the outcomes, since a poll started before the polling was restarted may complete concurrently with the new one
+	recordMutex          *sync.Mutex
+	consecutiveFailures  int
+	consecutiveSuccesses int
+}
+
+func newPollComponentCheck(name string, checkFn func() (bool, error), options PollOptions, clock Clock) *PollComponentCheck {
+	if options.Interval <= 0 {
+		options.Interval = DefaultPollInterval
+	}
+
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = 1
+	}
+
+	if options.SuccessThreshold <= 0 {
+		options.SuccessThreshold = 1
+	}
+
+	if options.BackoffBase <= 0 {
+		options.BackoffBase = options.Interval
+	}
+
+	return &PollComponentCheck{
+		name:      name,
+		isReady:   &atomic.Bool{},
+		checkedAt: &atomic.Pointer[time.Time]{},
+		latency:   &atomic.Int64{},
+		runMutex:  &sync.Mutex{},
+		clock:     clock,
+
+		recordMutex: &sync.Mutex{},
+
+		options: options,
+		checkFn: checkFn,
+		lastErr: &atomic.Pointer[error]{},
+	}
+}
 
-	pollDelay time.Duration
-	checkFn   func() bool
+// pollComponents returns the poll components among the given components, including the ones nested in composite checks
+func pollComponents(components []ComponentCheck) []*PollComponentCheck {
+	polls := make([]*PollComponentCheck, 0)
+
+	for _, component := range components {
+		switch check := component.(type) {
+		case *PollComponentCheck:
+			polls = append(polls, check)
+		case *CompositeComponentCheck:
+			polls = append(polls, pollComponents(check.checks)...)
+		}
+	}
+
+	return polls
 }
 
 // Name is the name of the component being checked for
@@ -26,19 +132,200 @@ func (component *PollComponentCheck) Ready() bool {
 	return component.isReady.Load()
 }
 
-// Start will poll the component every X amount of time. This is a blocking method.
+// Status returns [StatusDegraded] when the component is ready, but its last poll took longer than the
+// [PollOptions.LatencyThreshold]
+func (component *PollComponentCheck) Status() Status {
+	if !component.isReady.Load() {
+		return StatusNotReady
+	}
+
+	if component.options.LatencyThreshold > 0 && component.Latency() > component.options.LatencyThreshold {
+		return StatusDegraded
+	}
+
+	return StatusReady
+}
+
+// Latency returns the time taken by the last poll. It is zero if the component was never polled.
+func (component *PollComponentCheck) Latency() time.Duration {
+	return time.Duration(component.latency.Load())
+}
+
+// CheckLatencies returns the time taken by the last poll of each poll component which was polled
+func (rdy *ReadyCheck) CheckLatencies() map[string]time.Duration {
+	latencies := make(map[string]time.Duration)
+	for _, component := range rdy.snapshotComponents() {
+		if poll, ok := component.(*PollComponentCheck); ok && poll.checkedAt.Load() != nil {
+			latencies[poll.Name()] = poll.Latency()
+		}
+	}
+
+	return latencies
+}
+
+// Result returns the outcome of the last poll, and the time it took. While the component is not ready, the error of the last
+// failed poll is reported, if the check returned one.
+func (component *PollComponentCheck) Result() CheckResult {
+	result := CheckResult{
+		Ready:     component.isReady.Load(),
+		CheckedAt: loadTime(component.checkedAt),
+	}
+
+	if err := component.lastErr.Load(); err != nil && !result.Ready {
+		result.Err = *err
+	}
+
+	if !result.CheckedAt.IsZero() {
+		result.Details = map[string]string{"latency": component.Latency().String()}
+	}
+
+	return result
+}
+
+// Start will poll the component every X amount of time. This is a blocking method, which returns once the polling is stopped.
+// Calling Start while the component is already being polled returns immediately.
 func (component *PollComponentCheck) Start() {
-	component.isActive.Store(true)
+	ctx, started := component.begin()
+	if !started {
+		return
+	}
 
-	for component.isActive.Load() {
-		nextIsReady := component.checkFn()
-		component.isReady.Store(nextIsReady)
+	component.poll(ctx)
+}
+
+// begin marks the component as being polled, and returns the context cancelled when the polling is stopped. It returns false if
+// the component is already being polled.
+func (component *PollComponentCheck) begin() (context.Context, bool) {
+	component.runMutex.Lock()
+	defer component.runMutex.Unlock()
+
+	if component.cancel != nil {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	component.running = ctx
+	component.cancel = cancel
+
+	return ctx, true
+}
+
+// poll polls the component until the context is cancelled. The ticker is stopped while the check runs, so that the delay before
+// the next poll starts once the check completed.
+func (component *PollComponentCheck) poll(ctx context.Context) {
+	ticker := component.clock.NewTicker(component.options.Interval)
+	defer ticker.Stop()
 
-		time.Sleep(component.pollDelay)
+	for {
+		start := component.clock.Now()
+		passed, err := component.checkFn()
+		latency := component.clock.Now().Sub(start)
+		if ctx.Err() != nil {
+			// The outcome of a check completing after the polling was stopped is discarded
+			return
+		}
+
+		ticker.Reset(component.record(passed, err, latency))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			ticker.Stop()
+		}
 	}
 }
 
-// Stop will break the polling if it was previously started.
+// nextDelay returns the time to wait before the next poll, backing off exponentially while the polls are failing. Must be
+// called while holding the record mutex.
+func (component *PollComponentCheck) nextDelay() time.Duration {
+	if component.options.BackoffMax <= 0 || component.consecutiveFailures == 0 {
+		return component.options.Interval
+	}
+
+	delay := component.options.BackoffBase
+	for i := 1; i < component.consecutiveFailures && delay < component.options.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	if delay > component.options.BackoffMax {
+		return component.options.BackoffMax
+	}
+
+	return delay
+}
+
+// checkNow runs the check synchronously and records its outcome
+func (component *PollComponentCheck) checkNow() {
+	start := component.clock.Now()
+	passed, err := component.checkFn()
+	component.record(passed, err, component.clock.Now().Sub(start))
+}
+
+// record records the outcome of a poll, and updates the readiness once the outcome was consistent enough. It returns the time
+// to wait before the next poll.
+func (component *PollComponentCheck) record(passed bool, err error, latency time.Duration) time.Duration {
+	component.recordMutex.Lock()
+	defer component.recordMutex.Unlock()
+
+	now := component.clock.Now()
+	component.latency.Store(int64(latency))
+	component.checkedAt.Store(&now)
+
+	if passed {
+		component.recordHistory(StatusReady, nil, latency)
+	} else {
+		component.recordHistory(StatusNotReady, err, latency)
+
+		if err != nil {
+			component.lastErr.Store(&err)
+		}
+	}
+
+	if passed {
+		component.consecutiveSuccesses++
+		component.consecutiveFailures = 0
+	} else {
+		component.consecutiveFailures++
+		component.consecutiveSuccesses = 0
+	}
+
+	wasReady := component.isReady.Load()
+	nextIsReady := wasReady
+	if !wasReady && component.consecutiveSuccesses >= component.options.SuccessThreshold {
+		nextIsReady = true
+	} else if wasReady && component.consecutiveFailures >= component.options.FailureThreshold {
+		nextIsReady = false
+	}
+
+	if wasReady != nextIsReady {
+		component.isReady.Store(nextIsReady)
+		component.notifyChange()
+	}
+
+	return component.nextDelay()
+}
+
+// Stop will break the polling if it was previously started. The polling stops immediately, without waiting for the next poll.
 func (component *PollComponentCheck) Stop() {
-	component.isActive.Store(false)
+	component.runMutex.Lock()
+	defer component.runMutex.Unlock()
+
+	if component.cancel != nil {
+		component.cancel()
+		component.running = nil
+		component.cancel = nil
+	}
+}
+
+// stopRun stops the polling started with the given context. Polling started afterwards is left running.
+func (component *PollComponentCheck) stopRun(ctx context.Context) {
+	component.runMutex.Lock()
+	defer component.runMutex.Unlock()
+
+	if component.cancel != nil && component.running == ctx {
+		component.cancel()
+		component.running = nil
+		component.cancel = nil
+	}
 }
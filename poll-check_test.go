@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
 	assert2 "github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +37,182 @@ func Test_WhenStartingPoll_ShouldPollAtIntervals(t *testing.T) {
 
 	assert.LessOrEqual(calls.Load(), nbCalls+1, "should have call check no more than 1 extra time")
 }
+
+func Test_WhenThresholdsAreSet_ShouldRequireConsecutiveResults(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readyCheck.OnChange(recorder.onChange)
+
+	results := []bool{true, true, false, true, false, false, false, true, true}
+	calls := atomic.Int32{}
+
+	pollCheck := readyCheck.RegisterPollComponentWithOptions("db", func() bool {
+		call := int(calls.Add(1)) - 1
+		if call >= len(results) {
+			return true
+		}
+
+		return results[call]
+	}, lifecycle.PollOptions{
+		Interval:         time.Millisecond,
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+	})
+
+	go pollCheck.Start()
+	defer pollCheck.Stop()
+
+	assert.Eventually(func() bool {
+		return calls.Load() > int32(len(results))
+	}, time.Second, 5*time.Millisecond)
+
+	components, _ := recorder.snapshot()
+	assert.Equal([]string{"db:ready", "db:not-ready", "db:ready"}, components)
+}
+
+func Test_WhenBackoffIsSet_ShouldBackOffWhileFailing(t *testing.T) {
+	assert := assert2.New(t)
+
+	clock := lifecycletest.NewClock(time.Now())
+	readyCheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		Clock: clock,
+	})
+
+	results := []bool{false, false, false, true, true}
+	polled := make(chan struct{}, 10)
+	calls := atomic.Int32{}
+
+	pollCheck := readyCheck.RegisterPollComponentWithOptions("db", func() bool {
+		call := int(calls.Add(1)) - 1
+		polled <- struct{}{}
+
+		return call >= len(results) || results[call]
+	}, lifecycle.PollOptions{
+		Interval:    time.Millisecond,
+		BackoffBase: 50 * time.Millisecond,
+		BackoffMax:  80 * time.Millisecond,
+	})
+
+	go pollCheck.Start()
+	defer pollCheck.Stop()
+
+	<-polled
+
+	// The delay doubles with each failure up to the maximum, and the interval is restored after a success
+	for _, delay := range []time.Duration{50 * time.Millisecond, 80 * time.Millisecond, 80 * time.Millisecond, time.Millisecond} {
+		<-clock.TimersCreated(1)
+
+		clock.Advance(delay - time.Nanosecond)
+		assert.Empty(polled, "next poll should wait for %s", delay)
+
+		clock.Advance(time.Nanosecond)
+		select {
+		case <-polled:
+		case <-time.After(time.Second):
+			assert.Fail("next poll should wait no longer than " + delay.String())
+			return
+		}
+	}
+}
+
+func Test_WhenImmediateCheckIsSet_ShouldBeReadyBeforePolling(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	readyCheck.RegisterPollComponentWithOptions("db", func() bool {
+		return true
+	}, lifecycle.PollOptions{
+		Interval:       time.Hour,
+		ImmediateCheck: true,
+	})
+	readyCheck.RegisterPollComponent("cache", func() bool {
+		return true
+	}, time.Hour)
+
+	assert.Equal(map[string]bool{"db": true, "cache": false}, readyCheck.Explain())
+}
+
+func Test_WhenStoppingPoll_ShouldNotWaitForNextPoll(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	polled := make(chan struct{}, 1)
+	pollCheck := readyCheck.RegisterPollComponent("db", func() bool {
+		polled <- struct{}{}
+		return true
+	}, time.Hour)
+
+	stopped := make(chan struct{})
+	go func() {
+		pollCheck.Start()
+		close(stopped)
+	}()
+	<-polled
+
+	pollCheck.Stop()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		assert.Fail("polling should have stopped immediately")
+	}
+}
+
+func Test_WhenStartingPollTwice_ShouldPollOnce(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	calls := atomic.Int32{}
+	pollCheck := readyCheck.RegisterPollComponent("db", func() bool {
+		calls.Add(1)
+		return true
+	}, time.Hour)
+
+	go pollCheck.Start()
+	defer pollCheck.Stop()
+
+	assert.Eventually(func() bool {
+		return calls.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	returned := make(chan struct{})
+	go func() {
+		pollCheck.Start()
+		close(returned)
+	}()
+
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		assert.Fail("second start should have returned immediately")
+	}
+	assert.Equal(int32(1), calls.Load())
+}
+
+func Test_WhenPollIsSlow_ShouldReportLatencyAndDegrade(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pollCheck := readyCheck.RegisterPollComponentWithOptions("db", func() bool {
+		time.Sleep(20 * time.Millisecond)
+		return true
+	}, lifecycle.PollOptions{
+		Interval:         time.Hour,
+		LatencyThreshold: 10 * time.Millisecond,
+	})
+
+	assert.Empty(readyCheck.CheckLatencies(), "component was never polled")
+
+	go pollCheck.Start()
+	defer pollCheck.Stop()
+
+	assert.Eventually(pollCheck.Ready, time.Second, time.Millisecond)
+
+	assert.GreaterOrEqual(pollCheck.Latency(), 20*time.Millisecond)
+	assert.Equal(pollCheck.Latency(), readyCheck.CheckLatencies()["db"])
+	assert.Contains(pollCheck.Result().Details, "latency")
+	assert.Equal(lifecycle.StatusDegraded, pollCheck.Status())
+	assert.True(readyCheck.Ready(), "a slow component should still be ready")
+}
@@ -0,0 +1,36 @@
+package lifecycle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+var DefaultProbeTimeout = 5 * time.Second
+
+// ProbeHTTP sends a GET request to the given health endpoint and returns a process exit code: 0 if the endpoint responded with
+// a 2xx status, 1 otherwise. It is meant to implement container health checks, such as Docker's HEALTHCHECK CMD, without
+// requiring curl in the image. The request times out after [DefaultProbeTimeout].
+func ProbeHTTP(url string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 1
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 1
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return 1
+	}
+
+	return 0
+}
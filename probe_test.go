@@ -0,0 +1,34 @@
+package lifecycle_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenEndpointIsHealthy_ShouldProbeSuccessfully(t *testing.T) {
+	assert := assert2.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	assert.Equal(0, lifecycle.ProbeHTTP(srv.URL))
+}
+
+func Test_WhenEndpointIsUnhealthy_ShouldProbeUnsuccessfully(t *testing.T) {
+	assert := assert2.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	assert.Equal(1, lifecycle.ProbeHTTP(srv.URL))
+
+	srv.Close()
+	assert.Equal(1, lifecycle.ProbeHTTP(srv.URL), "unreachable endpoint should be unhealthy")
+}
@@ -0,0 +1,185 @@
+package lifecycle
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ShutdownProgress describes the progress of the shutdown process
+type ShutdownProgress struct {
+	// InProgress is true while the components are being awaited
+	InProgress bool
+	// Pending are the components which did not complete their shutdown yet
+	Pending []string
+	// Completed are the components which completed their shutdown, successfully or not
+	Completed []string
+	// Elapsed is the time elapsed since the components started being awaited
+	Elapsed time.Duration
+	// Remaining is the time left before the shutdown times out, while it is in progress
+	Remaining time.Duration
+}
+
+type progressTracker struct {
+	mutex *sync.RWMutex
+	clock Clock
+
+	startedAt  time.Time
+	finishedAt time.Time
+	deadline   time.Time
+	pending    map[string]bool
+	completed  []string
+	durations  map[string]time.Duration
+	outcomes   map[string]ComponentReport
+	leaks      []GoroutineLeak
+}
+
+func newProgressTracker(clock Clock) *progressTracker {
+	return &progressTracker{
+		mutex:     &sync.RWMutex{},
+		clock:     clock,
+		pending:   make(map[string]bool),
+		durations: make(map[string]time.Duration),
+		outcomes:  make(map[string]ComponentReport),
+	}
+}
+
+func (tracker *progressTracker) start(components []string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.startedAt = tracker.clock.Now()
+	tracker.finishedAt = time.Time{}
+	tracker.completed = make([]string, 0, len(components))
+	tracker.pending = make(map[string]bool, len(components))
+	tracker.durations = make(map[string]time.Duration, len(components))
+	tracker.outcomes = make(map[string]ComponentReport, len(components))
+	tracker.leaks = nil
+
+	for _, name := range components {
+		tracker.pending[name] = true
+	}
+}
+
+// setDeadline sets the time by which the shutdown must complete. It is set as soon as the AppContext is cancelled, before the
+// components start being awaited.
+func (tracker *progressTracker) setDeadline(deadline time.Time) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.deadline = deadline
+}
+
+// remaining returns the time left before the deadline. The mutex must be held.
+func (tracker *progressTracker) remaining() time.Duration {
+	if tracker.deadline.IsZero() {
+		return 0
+	}
+
+	remaining := tracker.deadline.Sub(tracker.clock.Now())
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+func (tracker *progressTracker) complete(name string, err error, duration time.Duration) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	delete(tracker.pending, name)
+	tracker.completed = append(tracker.completed, name)
+	tracker.durations[name] = duration
+	tracker.outcomes[name] = ComponentReport{
+		Outcome:  outcomeOf(err),
+		Err:      err,
+		Duration: duration,
+	}
+}
+
+// setLeaks records the goroutines suspected to have leaked, once the components are done shutting down
+func (tracker *progressTracker) setLeaks(leaks []GoroutineLeak) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.leaks = leaks
+}
+
+func (tracker *progressTracker) finish() {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.finishedAt = tracker.clock.Now()
+	tracker.deadline = time.Time{}
+}
+
+func (tracker *progressTracker) progress() ShutdownProgress {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+
+	progress := ShutdownProgress{
+		Pending:   make([]string, 0, len(tracker.pending)),
+		Completed: make([]string, len(tracker.completed)),
+	}
+
+	for name := range tracker.pending {
+		progress.Pending = append(progress.Pending, name)
+	}
+	sort.Strings(progress.Pending)
+
+	copy(progress.Completed, tracker.completed)
+
+	switch {
+	case tracker.startedAt.IsZero():
+	case tracker.finishedAt.IsZero():
+		progress.InProgress = true
+		progress.Elapsed = tracker.clock.Now().Sub(tracker.startedAt)
+		progress.Remaining = tracker.remaining()
+	default:
+		progress.Elapsed = tracker.finishedAt.Sub(tracker.startedAt)
+	}
+
+	return progress
+}
+
+func (tracker *progressTracker) report() ShutdownReport {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+
+	report := ShutdownReport{
+		Components: make(map[string]ComponentReport, len(tracker.outcomes)),
+		Durations:  make(map[string]time.Duration, len(tracker.durations)),
+	}
+
+	for name, duration := range tracker.durations {
+		report.Durations[name] = duration
+	}
+
+	for name, outcome := range tracker.outcomes {
+		report.Components[name] = outcome
+	}
+
+	if len(tracker.leaks) > 0 {
+		report.Leaks = make([]GoroutineLeak, len(tracker.leaks))
+		copy(report.Leaks, tracker.leaks)
+	}
+
+	return report
+}
+
+// Progress returns the progress of the shutdown. During an active shutdown, it reports which components are still pending and
+// which completed, in order of completion. Once the shutdown is over, it reports the final state of the last shutdown.
+func (gs *GracefulShutdown) Progress() ShutdownProgress {
+	return gs.progressTracker.progress()
+}
+
+// Remaining returns the time left before the shutdown in progress times out. It lets components which are not given the
+// shutdown context, such as the ones registered with RegisterComponent, decide to skip optional steps when time is short. It is
+// available as soon as the AppContext is cancelled, and returns 0 when no shutdown is in progress.
+func (gs *GracefulShutdown) Remaining() time.Duration {
+	gs.progressTracker.mutex.RLock()
+	defer gs.progressTracker.mutex.RUnlock()
+
+	return gs.progressTracker.remaining()
+}
@@ -0,0 +1,77 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenShutdownIsInProgress_ShouldReportProgress(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.False(gs.Progress().InProgress, "shutdown should not be in progress before being requested")
+
+	CreateSuccessComponent(gs, "fast", 0)
+	CreateSuccessComponent(gs, "kafka-consumer", 300*time.Millisecond)
+	CreateSuccessComponent(gs, "pg-pool", 300*time.Millisecond)
+
+	done := make(chan error)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	time.Sleep(150 * time.Millisecond)
+
+	progress := gs.Progress()
+	assert.True(progress.InProgress)
+	assert.Equal([]string{"kafka-consumer", "pg-pool"}, progress.Pending)
+	assert.Equal([]string{"fast"}, progress.Completed)
+	assert.GreaterOrEqual(progress.Elapsed, 150*time.Millisecond)
+
+	assert.NoError(<-done)
+
+	progress = gs.Progress()
+	assert.False(progress.InProgress)
+	assert.Empty(progress.Pending)
+	assert.Len(progress.Completed, 3)
+}
+
+func Test_WhenShutdownIsInProgress_ShouldExposeRemainingTime(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: time.Second,
+	})
+
+	assert.Equal(time.Duration(0), gs.Remaining(), "no shutdown is in progress")
+
+	shutdownChan, err := gs.RegisterComponent("consumer")
+	assert.NoError(err)
+
+	remainingAtSignal := make(chan time.Duration, 1)
+	go func() {
+		<-gs.AppContext().Done()
+		remainingAtSignal <- gs.Remaining()
+		shutdownChan <- nil
+	}()
+
+	var deadlineBudget time.Duration
+	assert.NoError(gs.RegisterComponentWithContext("producer", func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(ok, "context should carry the deadline")
+		deadlineBudget = time.Until(deadline)
+
+		return nil
+	}))
+
+	assert.NoError(gs.Shutdown())
+
+	remaining := <-remainingAtSignal
+	assert.Greater(remaining, 900*time.Millisecond)
+	assert.LessOrEqual(remaining, time.Second)
+	assert.Greater(deadlineBudget, 900*time.Millisecond)
+	assert.Equal(time.Duration(0), gs.Remaining(), "shutdown is over")
+}
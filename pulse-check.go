@@ -1,17 +1,52 @@
 package lifecycle
 
 import (
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// PulseOptions are options used in conjunction with the [PulseComponentCheck] type
+type PulseOptions struct {
+	// Expiration is the duration during which a pulse marks the component as being ready
+	Expiration time.Duration
+	// MinPulses is the number of pulses which must be recorded within the Window for the component to be ready. It allows
+	// reporting a heartbeat loop running far slower than expected as not ready.
+	//
+	// Default: 1
+	MinPulses int
+	// Window is the sliding window in which MinPulses pulses must be recorded
+	//
+	// Default: Expiration
+	Window time.Duration
+}
+
 // PulseComponentCheck performs readiness check based on a timeout. Each pulse marks
 // the component as being ready, until a given duration.
 type PulseComponentCheck struct {
-	name       string
-	expiration time.Duration
+	changeNotifier
+	historyRecorder
+
+	name    string
+	options PulseOptions
+
+	lastPulse  *atomic.Pointer[time.Time]
+	pulseCount *atomic.Uint64
+	loopExited *atomic.Bool
+
+	// recentPulses holds up to MinPulses of the most recent pulses, oldest first
+	pulsesMutex  *sync.Mutex
+	recentPulses []time.Time
 
-	lastPulse *atomic.Pointer[time.Time]
+	timerMutex  *sync.Mutex
+	expiryTimer *time.Timer
+
+	pulseChanOnce *sync.Once
+	pulseChan     chan struct{}
+
+	callbacksMutex  *sync.Mutex
+	expireCallbacks []func(name string, lastPulse time.Time)
 }
 
 // Name is the name of the component being checked for
@@ -19,20 +54,204 @@ func (component *PulseComponentCheck) Name() string {
 	return component.name
 }
 
-// Ready returns true if the last pulse recorded was before the expiration
+// Ready returns true if the last pulse recorded was before the expiration, and enough pulses were recorded within the window
 func (component *PulseComponentCheck) Ready() bool {
+	_, err := component.validUntil(time.Now())
+
+	return err == nil
+}
+
+// Result returns the readiness of the component, the time of the last pulse, and why the component is not ready
+func (component *PulseComponentCheck) Result() CheckResult {
+	now := time.Now()
+	_, err := component.validUntil(now)
+
+	result := CheckResult{
+		Ready:     err == nil,
+		Err:       err,
+		CheckedAt: now,
+	}
+	result.Details = map[string]string{"pulseCount": strconv.FormatUint(component.pulseCount.Load(), 10)}
+	if lastPulse := component.lastPulse.Load(); lastPulse != nil {
+		result.Details["lastPulse"] = lastPulse.Format(time.RFC3339Nano)
+		result.Details["sinceLastPulse"] = now.Sub(*lastPulse).String()
+	}
+
+	return result
+}
+
+// LastPulse returns the time of the last pulse recorded. It returns false if no pulse was recorded.
+func (component *PulseComponentCheck) LastPulse() (time.Time, bool) {
 	lastPulse := component.lastPulse.Load()
+	if lastPulse == nil {
+		return time.Time{}, false
+	}
+
+	return *lastPulse, true
+}
+
+// SinceLastPulse returns the time elapsed since the last pulse. It returns false if no pulse was recorded.
+func (component *PulseComponentCheck) SinceLastPulse() (time.Duration, bool) {
+	lastPulse, ok := component.LastPulse()
+	if !ok {
+		return 0, false
+	}
+
+	return time.Since(lastPulse), true
+}
+
+// PulseCount returns the number of pulses recorded since the component was registered
+func (component *PulseComponentCheck) PulseCount() uint64 {
+	return component.pulseCount.Load()
+}
 
+// validUntil returns the time until which the component stays ready without recording another pulse, or why it is not ready
+func (component *PulseComponentCheck) validUntil(now time.Time) (time.Time, error) {
+	if component.loopExited.Load() {
+		return time.Time{}, ErrPulseLoopExited
+	}
+
+	lastPulse := component.lastPulse.Load()
 	if lastPulse == nil {
-		return false
+		return time.Time{}, ErrPulseNotRecorded
+	}
+
+	until := lastPulse.Add(component.options.Expiration)
+	if now.After(until) {
+		return until, ErrPulseExpired
+	}
+
+	if component.options.MinPulses <= 1 {
+		return until, nil
+	}
+
+	component.pulsesMutex.Lock()
+	defer component.pulsesMutex.Unlock()
+
+	if len(component.recentPulses) < component.options.MinPulses {
+		return until, ErrPulseRateTooLow
+	}
+
+	windowUntil := component.recentPulses[0].Add(component.options.Window)
+	if now.After(windowUntil) {
+		return windowUntil, ErrPulseRateTooLow
+	}
+
+	if windowUntil.Before(until) {
+		until = windowUntil
 	}
 
-	return time.Since(*lastPulse) <= component.expiration
+	return until, nil
 }
 
 // RecordPulse records a pulse from the component and marks the component as being
 // alive until the state expires
 func (component *PulseComponentCheck) RecordPulse() {
 	now := time.Now()
+	_, errBefore := component.validUntil(now)
+
+	if component.options.MinPulses > 1 {
+		component.pulsesMutex.Lock()
+		component.recentPulses = append(component.recentPulses, now)
+		if len(component.recentPulses) > component.options.MinPulses {
+			component.recentPulses = component.recentPulses[1:]
+		}
+		component.pulsesMutex.Unlock()
+	}
+
 	component.lastPulse.Store(&now)
+	component.pulseCount.Add(1)
+
+	until, err := component.validUntil(now)
+	if err != nil {
+		component.recordHistory(StatusNotReady, err, 0)
+	} else {
+		component.recordHistory(StatusReady, nil, 0)
+
+		// The timer notifies the change once the component is no longer ready
+		component.timerMutex.Lock()
+		if component.expiryTimer == nil {
+			component.expiryTimer = time.AfterFunc(until.Sub(now), component.expire)
+		} else {
+			component.expiryTimer.Reset(until.Sub(now))
+		}
+		component.timerMutex.Unlock()
+	}
+
+	// Only a flip is notified, so that heartbeats do not cause the ReadyCheck to evaluate anything
+	if (errBefore == nil) != (err == nil) {
+		component.notifyChange()
+	}
+}
+
+// OnExpire registers a callback invoked with the time of the last pulse when the component is no longer ready because its pulse
+// expired, so that the loss of the pulse can be handled as it happens
+func (component *PulseComponentCheck) OnExpire(fn func(name string, lastPulse time.Time)) {
+	component.callbacksMutex.Lock()
+	defer component.callbacksMutex.Unlock()
+
+	component.expireCallbacks = append(component.expireCallbacks, fn)
+}
+
+// expire is invoked by the expiry timer once the component should no longer be ready
+func (component *PulseComponentCheck) expire() {
+	component.notifyChange()
+
+	_, err := component.validUntil(time.Now())
+	if err == nil {
+		// A pulse was recorded in the meantime
+		return
+	}
+
+	component.recordHistory(StatusNotReady, err, 0)
+
+	lastPulse, _ := component.LastPulse()
+
+	component.callbacksMutex.Lock()
+	callbacks := append([]func(string, time.Time){}, component.expireCallbacks...)
+	component.callbacksMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(component.name, lastPulse)
+	}
+}
+
+// PulseChan returns a channel recording a pulse for each value it receives, so that producers already emitting heartbeat events
+// can feed the check without holding a reference to it. The same channel is returned on each call; closing it stops recording
+// the pulses it receives.
+func (component *PulseComponentCheck) PulseChan() chan<- struct{} {
+	component.pulseChanOnce.Do(func() {
+		component.pulseChan = make(chan struct{}, 1)
+
+		go func() {
+			for range component.pulseChan {
+				component.RecordPulse()
+			}
+		}()
+	})
+
+	return component.pulseChan
+}
+
+// Wrap runs the given loop, which must call beat on each of its iterations. If the loop stops calling beat, for instance because
+// it is deadlocked, the pulse expires. Once the loop returns or panics, the component is reported as not ready until Wrap is
+// called again. This is a blocking method.
+func (component *PulseComponentCheck) Wrap(loop func(beat func())) {
+	component.loopExited.Store(false)
+	defer func() {
+		component.loopExited.Store(true)
+		component.stopExpiryTimer()
+		component.notifyChange()
+	}()
+
+	loop(component.RecordPulse)
+}
+
+func (component *PulseComponentCheck) stopExpiryTimer() {
+	component.timerMutex.Lock()
+	defer component.timerMutex.Unlock()
+
+	if component.expiryTimer != nil {
+		component.expiryTimer.Stop()
+	}
 }
@@ -42,3 +42,131 @@ func Test_WhenPulseCheckExpired_ShouldNotBeReady(t *testing.T) {
 
 	assert.False(pulse.Ready(), "pulse was recorded more than 50ms ago, should NOT be ready")
 }
+
+func Test_WhenPulseRateIsTooLow_ShouldNotBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pulse := readyCheck.RegisterPulseComponentWithOptions("heartbeat", lifecycle.PulseOptions{
+		Expiration: time.Second,
+		MinPulses:  3,
+		Window:     100 * time.Millisecond,
+	})
+
+	pulse.RecordPulse()
+	pulse.RecordPulse()
+	assert.False(pulse.Ready(), "not enough pulses were recorded")
+
+	pulse.RecordPulse()
+	assert.True(pulse.Ready())
+
+	time.Sleep(150 * time.Millisecond)
+	pulse.RecordPulse()
+
+	assert.False(pulse.Ready(), "pulses are too far apart")
+	assert.ErrorIs(pulse.Result().Err, lifecycle.ErrPulseRateTooLow)
+	assert.False(readyCheck.Ready())
+}
+
+func Test_WhenPulsesAreRecorded_ShouldExposeStatistics(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pulse := readyCheck.RegisterPulseComponent("pulse", time.Second)
+
+	_, ok := pulse.LastPulse()
+	assert.False(ok)
+	_, ok = pulse.SinceLastPulse()
+	assert.False(ok)
+	assert.Equal(uint64(0), pulse.PulseCount())
+
+	beforePulse := time.Now()
+	pulse.RecordPulse()
+	pulse.RecordPulse()
+
+	lastPulse, ok := pulse.LastPulse()
+	assert.True(ok)
+	assert.False(lastPulse.Before(beforePulse))
+
+	sinceLastPulse, ok := pulse.SinceLastPulse()
+	assert.True(ok)
+	assert.LessOrEqual(sinceLastPulse, time.Since(beforePulse))
+
+	assert.Equal(uint64(2), pulse.PulseCount())
+}
+
+func Test_WhenWrappedLoopIsRunning_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pulse := readyCheck.RegisterPulseComponent("worker", time.Second)
+
+	stop := make(chan struct{})
+	beating := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+
+		pulse.Wrap(func(beat func()) {
+			beat()
+			close(beating)
+			<-stop
+		})
+	}()
+
+	<-beating
+	assert.True(pulse.Ready())
+
+	close(stop)
+	<-exited
+
+	assert.False(pulse.Ready(), "component should not be ready once the loop exited")
+	assert.ErrorIs(pulse.Result().Err, lifecycle.ErrPulseLoopExited)
+}
+
+func Test_WhenPulseIsSentOnChannel_ShouldRecordPulse(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pulse := readyCheck.RegisterPulseComponent("consumer", time.Second)
+
+	pulses := pulse.PulseChan()
+	assert.Equal(pulses, pulse.PulseChan(), "the same channel should be returned")
+
+	pulses <- struct{}{}
+	pulses <- struct{}{}
+
+	assert.Eventually(func() bool {
+		return pulse.PulseCount() == 2
+	}, time.Second, time.Millisecond)
+	assert.True(pulse.Ready())
+
+	close(pulses)
+}
+
+func Test_WhenPulseExpires_ShouldInvokeExpireCallbacks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readyCheck := lifecycle.NewReadyCheck()
+	pulse := readyCheck.RegisterPulseComponent("worker", 30*time.Millisecond)
+
+	type expiration struct {
+		name      string
+		lastPulse time.Time
+	}
+	expirations := make(chan expiration, 1)
+	pulse.OnExpire(func(name string, lastPulse time.Time) {
+		expirations <- expiration{name: name, lastPulse: lastPulse}
+	})
+
+	pulse.RecordPulse()
+	lastPulse, _ := pulse.LastPulse()
+
+	select {
+	case expired := <-expirations:
+		assert.Equal("worker", expired.name)
+		assert.Equal(lastPulse, expired.lastPulse)
+	case <-time.After(time.Second):
+		assert.Fail("expire callback should have been invoked")
+	}
+}
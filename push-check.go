@@ -1,11 +1,26 @@
 package lifecycle
 
-import "sync/atomic"
+import (
+	"sync/atomic"
+	"time"
+)
 
 // PushComponentCheck performs a readiness check based on a manual input.
 type PushComponentCheck struct {
-	name    string
-	isReady *atomic.Bool
+	changeNotifier
+	historyRecorder
+
+	name      string
+	isReady   *atomic.Bool
+	degraded  *atomic.Bool
+	checkedAt *atomic.Pointer[time.Time]
+	outcome   *atomic.Pointer[pushOutcome]
+}
+
+// pushOutcome explains the last readiness set
+type pushOutcome struct {
+	reason string
+	err    error
 }
 
 // Name is the name of the component being checked for
@@ -20,5 +35,74 @@ func (component *PushComponentCheck) Ready() bool {
 
 // SetReady records the readiness check to be persisted
 func (component *PushComponentCheck) SetReady(isReady bool) {
-	component.isReady.Store(isReady)
+	component.set(isReady, pushOutcome{})
+}
+
+// SetReadyWithReason records the readiness check to be persisted, along with the reason explaining it, such as
+// "migrating schema". The reason is reported by [ReadyCheck.ExplainDetailed].
+func (component *PushComponentCheck) SetReadyWithReason(isReady bool, reason string) {
+	component.set(isReady, pushOutcome{reason: reason})
+}
+
+// SetError marks the component as not ready because of the given error, which is reported by [ReadyCheck.ExplainDetailed].
+// A nil error marks the component as ready.
+func (component *PushComponentCheck) SetError(err error) {
+	component.set(err == nil, pushOutcome{err: err})
+}
+
+func (component *PushComponentCheck) set(isReady bool, outcome pushOutcome) {
+	now := time.Now()
+	wasReady := component.isReady.Swap(isReady)
+	component.degraded.Store(false)
+	component.outcome.Store(&outcome)
+	component.checkedAt.Store(&now)
+	component.recordHistory(component.Status(), outcome.err, 0)
+
+	if wasReady != isReady {
+		component.notifyChange()
+	}
+}
+
+// SetStatus records the status of the component. A [StatusDegraded] component is still considered ready.
+func (component *PushComponentCheck) SetStatus(status Status) {
+	now := time.Now()
+	wasReady := component.isReady.Swap(status != StatusNotReady)
+	component.degraded.Store(status == StatusDegraded)
+	component.outcome.Store(&pushOutcome{})
+	component.checkedAt.Store(&now)
+	component.recordHistory(status, nil, 0)
+
+	if wasReady != (status != StatusNotReady) {
+		component.notifyChange()
+	}
+}
+
+// Status returns the last status set
+func (component *PushComponentCheck) Status() Status {
+	if !component.isReady.Load() {
+		return StatusNotReady
+	}
+
+	if component.degraded.Load() {
+		return StatusDegraded
+	}
+
+	return StatusReady
+}
+
+// Result returns the last readiness set, the reason or error explaining it, and the time at which it was set
+func (component *PushComponentCheck) Result() CheckResult {
+	result := CheckResult{
+		Ready:     component.isReady.Load(),
+		CheckedAt: loadTime(component.checkedAt),
+	}
+
+	if outcome := component.outcome.Load(); outcome != nil {
+		result.Err = outcome.err
+		if outcome.reason != "" {
+			result.Details = map[string]string{"reason": outcome.reason}
+		}
+	}
+
+	return result
 }
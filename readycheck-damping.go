@@ -0,0 +1,50 @@
+package lifecycle
+
+import "time"
+
+// readinessDamping holds the overall readiness reported while a change has not persisted for the hold-down duration
+type readinessDamping struct {
+	known        bool
+	reported     bool
+	pendingSince time.Time
+}
+
+// damp returns the readiness to report given the current readiness. Until the components are ready for the first time, the
+// readiness is reported as is, so that the startup is not delayed. When a change starts, a timer evaluates the readiness again
+// once the hold-down elapsed, so that the change is reported to the callbacks and subscribers even when [ReadyCheck.Ready] is
+// not called in the meantime.
+func (rdy *ReadyCheck) damp(isReady bool) bool {
+	if rdy.options.HoldDown <= 0 {
+		return isReady
+	}
+
+	rdy.dampingMutex.Lock()
+	defer rdy.dampingMutex.Unlock()
+
+	damping := &rdy.damping
+	now := rdy.options.Clock.Now()
+
+	switch {
+	case !damping.known:
+		damping.known = isReady
+		damping.reported = isReady
+	case isReady == damping.reported:
+		damping.pendingSince = time.Time{}
+	case damping.pendingSince.IsZero():
+		damping.pendingSince = now
+		go rdy.awaitHoldDown()
+	case now.Sub(damping.pendingSince) >= rdy.options.HoldDown:
+		damping.reported = isReady
+		damping.pendingSince = time.Time{}
+	}
+
+	return damping.reported
+}
+
+// awaitHoldDown evaluates the overall readiness again once the hold-down elapsed. A change which did not persist is discarded
+// by the evaluation.
+func (rdy *ReadyCheck) awaitHoldDown() {
+	<-rdy.options.Clock.After(rdy.options.HoldDown)
+
+	rdy.reevaluate()
+}
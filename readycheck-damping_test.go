@@ -0,0 +1,99 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenHoldDownIsSet_ShouldSuppressShortLivedChanges(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		HoldDown: 50 * time.Millisecond,
+	})
+	db := readycheck.RegisterPushComponent("db")
+	db.SetReady(true)
+
+	assert.True(readycheck.Ready())
+
+	db.SetReady(false)
+	assert.True(readycheck.Ready(), "change should be suppressed during the hold-down")
+
+	db.SetReady(true)
+	assert.True(readycheck.Ready())
+
+	db.SetReady(false)
+	assert.True(readycheck.Ready())
+	time.Sleep(60 * time.Millisecond)
+	assert.False(readycheck.Ready(), "change should be reported once it persisted")
+
+	db.SetReady(true)
+	readycheck.SetDraining(true)
+	assert.False(readycheck.Ready(), "drain mode should be reported immediately")
+}
+
+func Test_WhenHoldDownIsSet_ShouldStopWaitingOnceReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		HoldDown: 50 * time.Millisecond,
+	})
+	db := readycheck.RegisterPushComponent("db")
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		db.SetReady(true)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(readycheck.WaitUntilReady(ctx))
+	assert.True(readycheck.Ready())
+}
+
+func Test_WhenHoldDownElapses_ShouldPublishChange(t *testing.T) {
+	assert := assert2.New(t)
+
+	clock := lifecycletest.NewClock(time.Now())
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		HoldDown: 50 * time.Millisecond,
+		Clock:    clock,
+	})
+	recorder := &transitionRecorder{}
+	readycheck.OnReadyChange(recorder.onReadyChange)
+
+	db := readycheck.RegisterPushComponent("db")
+	db.SetReady(true)
+
+	db.SetReady(false)
+	db.SetReady(true)
+	<-clock.TimersCreated(1)
+	clock.Advance(50 * time.Millisecond)
+
+	_, ready := recorder.snapshot()
+	assert.Equal([]bool{true}, ready, "short-lived change should not be published")
+
+	db.SetReady(false)
+	<-clock.TimersCreated(1)
+	clock.Advance(49 * time.Millisecond)
+
+	_, ready = recorder.snapshot()
+	assert.Equal([]bool{true}, ready, "change should not be published during the hold-down")
+
+	clock.Advance(time.Millisecond)
+
+	assert.Eventually(func() bool {
+		_, ready := recorder.snapshot()
+		return len(ready) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	_, ready = recorder.snapshot()
+	assert.Equal([]bool{true, false}, ready)
+	assert.False(readycheck.Ready())
+}
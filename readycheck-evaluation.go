@@ -0,0 +1,193 @@
+package lifecycle
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+var ErrCheckTimeout = errors.New("check took too long to complete")
+
+// snapshotComponents returns a snapshot of the registered components, so they can be checked without holding the lock
+func (rdy *ReadyCheck) snapshotComponents() []ComponentCheck {
+	rdy.componentsMutex.RLock()
+	defer rdy.componentsMutex.RUnlock()
+
+	return append([]ComponentCheck{}, rdy.components...)
+}
+
+// isNonBlocking returns true for the built-in checks which only read their last recorded state
+func isNonBlocking(component ComponentCheck) bool {
+	switch component.(type) {
+	case *PushComponentCheck, *PulseComponentCheck, *PollComponentCheck, *GateComponentCheck:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkComponents evaluates the components concurrently. The built-in checks which do not block are evaluated inline. When a
+// check does not complete within the check timeout, the overrun value is used instead.
+func checkComponents[T any](components []ComponentCheck, timeout time.Duration, check func(ComponentCheck) T, overrun func(ComponentCheck) T) []T {
+	results := make([]T, len(components))
+	pending := make(map[int]chan T)
+
+	for i, component := range components {
+		if isNonBlocking(component) {
+			results[i] = check(component)
+			continue
+		}
+
+		result := make(chan T, 1)
+		pending[i] = result
+
+		go func(component ComponentCheck) {
+			result <- check(component)
+		}(component)
+	}
+
+	if len(pending) == 0 {
+		return results
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	timedOut := false
+	for i, result := range pending {
+		if !timedOut {
+			select {
+			case results[i] = <-result:
+				continue
+			case <-deadline.C:
+				timedOut = true
+			}
+		}
+
+		// Once the timeout expired, only the checks which already completed are collected
+		select {
+		case results[i] = <-result:
+		default:
+			results[i] = overrun(components[i])
+		}
+	}
+
+	return results
+}
+
+// componentEvaluation is the outcome of a single evaluation of a component, from which its readiness, its status and its
+// detailed result are all reported, so that they never disagree
+type componentEvaluation struct {
+	status Status
+	result CheckResult
+}
+
+// evaluateComponents evaluates each component once, concurrently. See [checkComponents].
+func (rdy *ReadyCheck) evaluateComponents(components []ComponentCheck) []componentEvaluation {
+	return checkComponents(components, rdy.options.CheckTimeout, rdy.evaluateComponent, func(ComponentCheck) componentEvaluation {
+		return componentEvaluation{status: StatusNotReady, result: CheckResult{Err: ErrCheckTimeout, CheckedAt: time.Now()}}
+	})
+}
+
+func (rdy *ReadyCheck) evaluateComponent(component ComponentCheck) componentEvaluation {
+	start := time.Now()
+	evaluation := componentEvaluation{}
+
+	switch check := component.(type) {
+	case interface{ check() (Status, CheckResult) }:
+		evaluation.status, evaluation.result = check.check()
+		evaluation.result.Ready = evaluation.status != StatusNotReady
+	case DetailedCheck:
+		evaluation.result = check.Result()
+		evaluation.status = StatusNotReady
+		if evaluation.result.Ready {
+			evaluation.status = StatusReady
+			if statusCheck, ok := component.(StatusCheck); ok {
+				evaluation.status = statusCheck.Status()
+			}
+		}
+	default:
+		evaluation.status = componentStatus(component)
+		evaluation.result = CheckResult{Ready: evaluation.status != StatusNotReady, CheckedAt: time.Now()}
+	}
+
+	if !isNonBlocking(component) {
+		rdy.recordCheck(component, HistoryEntry{At: start, Status: evaluation.status, Err: evaluation.result.Err, Latency: time.Since(start)})
+	}
+
+	return evaluation
+}
+
+// outcomes returns the readiness and the status of each evaluated component
+func outcomes(evaluations []componentEvaluation) ([]bool, []Status) {
+	readiness := make([]bool, len(evaluations))
+	statuses := make([]Status, len(evaluations))
+
+	for i, evaluation := range evaluations {
+		readiness[i] = evaluation.result.Ready
+		statuses[i] = evaluation.status
+	}
+
+	return readiness, statuses
+}
+
+type cachedReadiness struct {
+	ready     bool
+	expiresAt time.Time
+}
+
+func (rdy *ReadyCheck) checkReadiness(components []ComponentCheck) []bool {
+	if rdy.options.CacheTTL <= 0 {
+		return checkComponents(components, rdy.options.CheckTimeout, rdy.checkAndRecord, func(ComponentCheck) bool {
+			return false
+		})
+	}
+
+	now := time.Now()
+	readiness := make([]bool, len(components))
+	uncached := make([]ComponentCheck, 0)
+	uncachedIndexes := make([]int, 0)
+
+	rdy.cacheMutex.Lock()
+	for i, component := range components {
+		if !isNonBlocking(component) {
+			if cached, ok := rdy.cache[component.Name()]; ok && now.Before(cached.expiresAt) {
+				readiness[i] = cached.ready
+				continue
+			}
+		}
+
+		uncached = append(uncached, component)
+		uncachedIndexes = append(uncachedIndexes, i)
+	}
+	rdy.cacheMutex.Unlock()
+
+	results := checkComponents(uncached, rdy.options.CheckTimeout, rdy.checkAndRecord, func(ComponentCheck) bool {
+		return false
+	})
+
+	rdy.cacheMutex.Lock()
+	defer rdy.cacheMutex.Unlock()
+
+	for i, component := range uncached {
+		readiness[uncachedIndexes[i]] = results[i]
+
+		if !isNonBlocking(component) {
+			ttl := rdy.options.CacheTTL
+			if rdy.options.CacheJitter > 0 {
+				ttl += time.Duration(rand.Int63n(int64(rdy.options.CacheJitter)))
+			}
+
+			rdy.cache[component.Name()] = cachedReadiness{ready: results[i], expiresAt: now.Add(ttl)}
+		}
+	}
+
+	return readiness
+}
+
+func (rdy *ReadyCheck) invalidateCache(name string) {
+	rdy.cacheMutex.Lock()
+	defer rdy.cacheMutex.Unlock()
+
+	delete(rdy.cache, name)
+}
@@ -0,0 +1,98 @@
+package lifecycle_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type slowComponentCheck struct {
+	name  string
+	delay time.Duration
+}
+
+func (c slowComponentCheck) Name() string {
+	return c.name
+}
+
+func (c slowComponentCheck) Ready() bool {
+	time.Sleep(c.delay)
+	return true
+}
+
+func Test_WhenChecksAreSlow_ShouldEvaluateThemConcurrently(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("slow-1", slowComponentCheck{name: "slow-1", delay: 100 * time.Millisecond})
+	readycheck.RegisterComponent("slow-2", slowComponentCheck{name: "slow-2", delay: 100 * time.Millisecond})
+	readycheck.RegisterComponent("slow-3", slowComponentCheck{name: "slow-3", delay: 100 * time.Millisecond})
+
+	start := time.Now()
+	assert.True(readycheck.Ready())
+	assert.Less(time.Since(start), 250*time.Millisecond, "checks should be evaluated concurrently")
+}
+
+func Test_WhenCheckTimesOut_ShouldBeNotReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		CheckTimeout: 50 * time.Millisecond,
+	})
+	readycheck.RegisterPushComponent("db").SetReady(true)
+	readycheck.RegisterComponent("stuck", slowComponentCheck{name: "stuck", delay: 500 * time.Millisecond})
+
+	start := time.Now()
+	assert.False(readycheck.Ready(), "overrunning check should be not ready")
+	assert.Less(time.Since(start), 250*time.Millisecond, "probe latency should be bounded by the check timeout")
+
+	assert.Equal(map[string]bool{"db": true, "stuck": false}, readycheck.Explain())
+	assert.ErrorIs(readycheck.ExplainDetailed()["stuck"].Err, lifecycle.ErrCheckTimeout)
+	assert.Equal(lifecycle.StatusNotReady, readycheck.Status())
+}
+
+type countingComponentCheck struct {
+	name  string
+	calls *atomic.Int32
+}
+
+func (c countingComponentCheck) Name() string {
+	return c.name
+}
+
+func (c countingComponentCheck) Ready() bool {
+	c.calls.Add(1)
+	return true
+}
+
+func Test_WhenCacheIsEnabled_ShouldReuseCheckResultsUntilExpiration(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{
+		CacheTTL:    50 * time.Millisecond,
+		CacheJitter: time.Millisecond,
+	})
+
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("expensive", countingComponentCheck{name: "expensive", calls: calls})
+	push := readycheck.RegisterPushComponent("push")
+	push.SetReady(true)
+
+	callsBefore := calls.Load()
+	for i := 0; i < 10; i++ {
+		assert.True(readycheck.Ready())
+		assert.Len(readycheck.Explain(), 2)
+	}
+	assert.LessOrEqual(calls.Load()-callsBefore, int32(1), "expensive check should be cached")
+
+	push.SetReady(false)
+	assert.False(readycheck.Ready(), "built-in checks should not be cached")
+
+	time.Sleep(60 * time.Millisecond)
+	callsBefore = calls.Load()
+	readycheck.Ready()
+	assert.Equal(callsBefore+1, calls.Load(), "expensive check should be evaluated once the cache expired")
+}
@@ -0,0 +1,72 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReadinessReport is the JSON document served by [ReadyCheck.Handler]
+type ReadinessReport struct {
+	// Ready is true if all components are ready
+	Ready bool `json:"ready"`
+	// Status is the worst status among the components
+	Status Status `json:"status"`
+	// Components maps each component's name to its readiness
+	Components map[string]bool `json:"components"`
+	// Warnings lists the degraded components, and the non-critical components which are not ready
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Handler returns an [http.Handler] reporting the readiness of the components, typically served as a Kubernetes readiness
+// probe. Each component is checked once per request. It responds with a 200 OK status when all components are ready, even if
+// some are degraded, and a 503 Service Unavailable status otherwise. The body is a JSON [ReadinessReport], listing the degraded and non-critical components as
+// warnings.
+func (rdy *ReadyCheck) Handler() http.Handler {
+	return rdy.handler(nil)
+}
+
+// HandlerForTags returns an [http.Handler] reporting the readiness of the components tagged with at least one of the given
+// tags. See [ReadyCheck.Handler].
+func (rdy *ReadyCheck) HandlerForTags(tags ...string) http.Handler {
+	return rdy.handler(tags)
+}
+
+func (rdy *ReadyCheck) handler(tags []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The components are evaluated once, so that the fields of the report do not disagree
+		components := rdy.selectComponents(tags)
+		evaluations := rdy.evaluateComponents(components)
+		readiness, statuses := outcomes(evaluations)
+
+		report := ReadinessReport{
+			Ready:      rdy.reportReadiness(tags, components, readiness),
+			Status:     rdy.worstStatus(components, statuses),
+			Components: make(map[string]bool, len(components)),
+		}
+
+		componentStatuses := make(map[string]Status, len(components))
+		for i, component := range components {
+			report.Components[component.Name()] = readiness[i]
+			componentStatuses[component.Name()] = statuses[i]
+		}
+
+		if warnings := rdy.warnings(componentStatuses); len(warnings) > 0 {
+			report.Warnings = warnings
+		}
+
+		// A failure suppressed by the hold-down is reported as degraded, not to contradict the readiness
+		if report.Ready && report.Status == StatusNotReady {
+			report.Status = StatusDegraded
+		}
+
+		status := http.StatusOK
+		if !report.Ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
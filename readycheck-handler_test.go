@@ -0,0 +1,88 @@
+package lifecycle_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func serveReadiness(rdy *lifecycle.ReadyCheck) (int, lifecycle.ReadinessReport) {
+	recorder := httptest.NewRecorder()
+	rdy.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	report := lifecycle.ReadinessReport{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &report)
+
+	return recorder.Code, report
+}
+
+func Test_WhenComponentsAreReady_ShouldServeOK(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPushComponent("db").SetReady(true)
+
+	code, report := serveReadiness(rdy)
+
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(lifecycle.ReadinessReport{
+		Ready:      true,
+		Status:     lifecycle.StatusReady,
+		Components: map[string]bool{"db": true},
+	}, report)
+}
+
+func Test_WhenComponentIsNotReady_ShouldServeServiceUnavailable(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPushComponent("db").SetReady(true)
+	rdy.RegisterPushComponent("cache")
+
+	code, report := serveReadiness(rdy)
+
+	assert.Equal(http.StatusServiceUnavailable, code)
+	assert.Equal(lifecycle.ReadinessReport{
+		Ready:      false,
+		Status:     lifecycle.StatusNotReady,
+		Components: map[string]bool{"db": true, "cache": false},
+	}, report)
+}
+
+func Test_WhenComponentIsDegraded_ShouldServeOKWithWarnings(t *testing.T) {
+	assert := assert2.New(t)
+
+	rdy := lifecycle.NewReadyCheck()
+	rdy.RegisterPushComponent("db").SetReady(true)
+	rdy.RegisterPushComponent("cache").SetStatus(lifecycle.StatusDegraded)
+
+	code, report := serveReadiness(rdy)
+
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(lifecycle.ReadinessReport{
+		Ready:      true,
+		Status:     lifecycle.StatusDegraded,
+		Components: map[string]bool{"db": true, "cache": true},
+		Warnings:   []string{"cache"},
+	}, report)
+}
+
+func Test_WhenReadinessIsServed_ShouldCheckEachComponentOnce(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("remote", countingComponentCheck{name: "remote", calls: calls})
+
+	status, report := serveReadiness(readycheck)
+	assert.Equal(http.StatusOK, status)
+	assert.True(report.Ready)
+	assert.Equal(lifecycle.StatusReady, report.Status)
+	assert.Equal(map[string]bool{"remote": true}, report.Components)
+	assert.Equal(int32(1), calls.Load(), "component should be checked once per request")
+}
@@ -0,0 +1,360 @@
+package lifecycle
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// ReadyEvent is a change of readiness, either of a component, or of the whole ReadyCheck
+type ReadyEvent struct {
+	// Component is the name of the component which changed. It is empty when the overall readiness changed.
+	Component string
+	// WasReady is the readiness before the change
+	WasReady bool
+	// Ready is the readiness after the change
+	Ready bool
+	// Draining is true if the ReadyCheck is in drain mode. It is only set for changes of the overall readiness.
+	Draining bool
+	// At is the time at which the change was detected
+	At time.Time
+}
+
+// subscriptionBufferSize is the number of events buffered for each subscriber before events are dropped
+const subscriptionBufferSize = 16
+
+// changeNotifier is embedded by the built-in checks to notify the ReadyCheck they are registered in of their changes
+type changeNotifier struct {
+	notifyFn atomic.Pointer[func()]
+}
+
+func (notifier *changeNotifier) setNotify(fn func()) {
+	notifier.notifyFn.Store(&fn)
+}
+
+func (notifier *changeNotifier) clearNotify() {
+	notifier.notifyFn.Store(nil)
+}
+
+func (notifier *changeNotifier) notifyChange() {
+	if fn := notifier.notifyFn.Load(); fn != nil {
+		(*fn)()
+	}
+}
+
+// OnChange registers a callback invoked when a component's readiness changes. Changes of the built-in checks are detected as
+// they happen. Other [ComponentCheck] implementations are evaluated in the background when callbacks or subscribers are
+// registered, and when any built-in check changes, so their changes are detected later.
+//
+// Callbacks are invoked synchronously and in order, and should therefore return quickly.
+func (rdy *ReadyCheck) OnChange(callback func(component string, ready bool)) {
+	rdy.transitionMutex.Lock()
+	rdy.changeCallbacks = append(rdy.changeCallbacks, callback)
+	rdy.transitionMutex.Unlock()
+
+	// Establishes the state of the checks which do not notify their changes
+	rdy.refreshCustomChecks()
+}
+
+// OnReadyChange registers a callback invoked when the overall readiness flips, including when the ReadyCheck starts or stops
+// draining. When [ReadyCheckOptions.HoldDown] is set, a flip is reported once it persisted for the hold-down duration, like
+// [ReadyCheck.Ready] does. See [ReadyCheck.OnChange].
+func (rdy *ReadyCheck) OnReadyChange(callback func(ready bool)) {
+	rdy.transitionMutex.Lock()
+	rdy.readyChangeCallbacks = append(rdy.readyChangeCallbacks, callback)
+	rdy.transitionMutex.Unlock()
+
+	// Establishes the state of the checks which do not notify their changes
+	rdy.refreshCustomChecks()
+}
+
+// Subscribe returns a channel receiving the readiness changes, both of the components and of the whole ReadyCheck. Entering
+// or leaving the drain mode is received as a change of the whole ReadyCheck, even when its readiness stays the same. Delivery
+// never blocks: events are dropped when the subscriber falls too far behind. Call [ReadyCheck.Unsubscribe] once the events
+// are no longer consumed.
+func (rdy *ReadyCheck) Subscribe() <-chan ReadyEvent {
+	subscriber := make(chan ReadyEvent, subscriptionBufferSize)
+
+	rdy.transitionMutex.Lock()
+	rdy.subscribers = append(rdy.subscribers, subscriber)
+	rdy.transitionMutex.Unlock()
+
+	rdy.refreshCustomChecks()
+
+	return subscriber
+}
+
+// Unsubscribe stops delivering events to a channel returned by [ReadyCheck.Subscribe], and closes it
+func (rdy *ReadyCheck) Unsubscribe(events <-chan ReadyEvent) {
+	rdy.transitionMutex.Lock()
+	defer rdy.transitionMutex.Unlock()
+
+	for i, subscriber := range rdy.subscribers {
+		if subscriber == events {
+			rdy.subscribers = append(rdy.subscribers[:i:i], rdy.subscribers[i+1:]...)
+			close(subscriber)
+			return
+		}
+	}
+}
+
+// WaitUntilReady blocks until all components are ready, or until the context is done. The readiness is evaluated when the
+// components change, see [ReadyCheck.OnChange], once a change persisted for the [ReadyCheckOptions.HoldDown], and every
+// [ReadyCheckOptions.RefreshInterval], so that custom checks recovering on their own are detected.
+func (rdy *ReadyCheck) WaitUntilReady(ctx context.Context) error {
+	events := rdy.Subscribe()
+	defer rdy.Unsubscribe(events)
+
+	for !rdy.Ready() {
+		select {
+		case <-events:
+		case <-rdy.options.Clock.After(rdy.options.RefreshInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// ObservedReadiness returns the readiness last observed when detecting the transitions, without evaluating the components: the
+// overall readiness, as last reported to [ReadyCheck.OnReadyChange], and the readiness of each component, as last reported to
+// [ReadyCheck.OnChange]. Components which were never evaluated are omitted, and only count towards the overall readiness once
+// they were. Checks other than the built-in ones are only observed while callbacks or subscribers are registered.
+func (rdy *ReadyCheck) ObservedReadiness() (ready bool, components map[string]bool) {
+	rdy.transitionMutex.Lock()
+	defer rdy.transitionMutex.Unlock()
+
+	components = make(map[string]bool, len(rdy.componentStates))
+	for name, isReady := range rdy.componentStates {
+		components[name] = isReady
+	}
+
+	return rdy.readyKnown && rdy.lastReady, components
+}
+
+// componentChanged records the readiness of a built-in check which notified a change, and dispatches the transitions it
+// causes. Only the notifying check is evaluated, so that notifying never waits for the other checks. The other checks are
+// refreshed in the background, see [ReadyCheck.refreshCustomChecks].
+func (rdy *ReadyCheck) componentChanged(component ComponentCheck) {
+	rdy.transitionMutex.Lock()
+	isReady := component.Ready()
+	if wasReady, known := rdy.componentStates[component.Name()]; known && wasReady == isReady {
+		rdy.transitionMutex.Unlock()
+		return
+	}
+
+	rdy.observe([]ComponentCheck{component}, []bool{isReady})
+	rdy.transitionMutex.Unlock()
+
+	rdy.dispatch()
+	rdy.refreshCustomChecks()
+}
+
+// reevaluate derives the overall readiness from the recorded states once the components or the drain mode changed, and
+// dispatches the resulting transition
+func (rdy *ReadyCheck) reevaluate() {
+	rdy.transitionMutex.Lock()
+	rdy.observe(nil, nil)
+	rdy.transitionMutex.Unlock()
+
+	rdy.dispatch()
+}
+
+// refreshCustomChecks evaluates in the background the checks which do not notify their changes, so that their transitions are
+// detected. The checks are only evaluated when callbacks or subscribers are registered, and at most once per
+// [ReadyCheckOptions.RefreshInterval]: refreshes requested in the meantime are coalesced into a single one, performed once the
+// interval elapsed.
+func (rdy *ReadyCheck) refreshCustomChecks() {
+	rdy.transitionMutex.Lock()
+	defer rdy.transitionMutex.Unlock()
+
+	if !rdy.hasListeners() || len(rdy.customComponents()) == 0 {
+		return
+	}
+
+	if rdy.refreshing {
+		rdy.refreshAgain = true
+		return
+	}
+
+	wait := rdy.lastRefresh.Add(rdy.options.RefreshInterval).Sub(rdy.options.Clock.Now())
+	if wait > 0 {
+		if !rdy.refreshScheduled {
+			rdy.refreshScheduled = true
+			go rdy.scheduleRefresh(wait)
+		}
+		return
+	}
+
+	rdy.refreshing = true
+	rdy.lastRefresh = rdy.options.Clock.Now()
+	go rdy.refresh()
+}
+
+// scheduleRefresh refreshes the custom checks once the given delay elapsed
+func (rdy *ReadyCheck) scheduleRefresh(delay time.Duration) {
+	<-rdy.options.Clock.After(delay)
+
+	rdy.transitionMutex.Lock()
+	rdy.refreshScheduled = false
+	rdy.transitionMutex.Unlock()
+
+	rdy.refreshCustomChecks()
+}
+
+func (rdy *ReadyCheck) refresh() {
+	components := rdy.customComponents()
+	readiness := rdy.checkReadiness(components)
+
+	rdy.transitionMutex.Lock()
+	rdy.observe(components, readiness)
+	again := rdy.refreshAgain
+	rdy.refreshAgain = false
+	rdy.refreshing = false
+	rdy.transitionMutex.Unlock()
+
+	rdy.dispatch()
+
+	if again {
+		rdy.refreshCustomChecks()
+	}
+}
+
+// hasListeners returns true if callbacks or subscribers are registered. Must be called while holding the transition mutex.
+func (rdy *ReadyCheck) hasListeners() bool {
+	return len(rdy.changeCallbacks) > 0 || len(rdy.readyChangeCallbacks) > 0 || len(rdy.subscribers) > 0
+}
+
+// customComponents returns the registered components which do not notify their changes
+func (rdy *ReadyCheck) customComponents() []ComponentCheck {
+	custom := make([]ComponentCheck, 0)
+	for _, component := range rdy.snapshotComponents() {
+		if !isNonBlocking(component) {
+			custom = append(custom, component)
+		}
+	}
+
+	return custom
+}
+
+// dispatch invokes the callbacks and notifies the subscribers of the pending transitions. Transitions detected while
+// dispatching, for instance because a callback updated a component, are dispatched by the goroutine already dispatching, in
+// order.
+func (rdy *ReadyCheck) dispatch() {
+	rdy.transitionMutex.Lock()
+	if rdy.dispatching || len(rdy.pendingTransitions) == 0 {
+		rdy.transitionMutex.Unlock()
+		return
+	}
+
+	rdy.dispatching = true
+	rdy.transitionMutex.Unlock()
+
+	for {
+		rdy.transitionMutex.Lock()
+		transitions := rdy.pendingTransitions
+		rdy.pendingTransitions = nil
+		if len(transitions) == 0 {
+			rdy.dispatching = false
+			rdy.transitionMutex.Unlock()
+			return
+		}
+
+		changeCallbacks := rdy.changeCallbacks
+		readyChangeCallbacks := rdy.readyChangeCallbacks
+		rdy.transitionMutex.Unlock()
+
+		for _, event := range transitions {
+			if event.Component == "" && event.WasReady != event.Ready {
+				for _, callback := range readyChangeCallbacks {
+					callback(event.Ready)
+				}
+			} else if event.Component != "" {
+				for _, callback := range changeCallbacks {
+					callback(event.Component, event.Ready)
+				}
+			}
+
+			rdy.publish(event)
+		}
+	}
+}
+
+func (rdy *ReadyCheck) publish(event ReadyEvent) {
+	rdy.transitionMutex.Lock()
+	defer rdy.transitionMutex.Unlock()
+
+	for _, subscriber := range rdy.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// observe records the readiness of the given components, and queues the transitions it causes. Components evaluated for the
+// first time, or which were unregistered in the meantime, do not cause a transition. Must be called while holding the
+// transition mutex.
+func (rdy *ReadyCheck) observe(components []ComponentCheck, readiness []bool) {
+	registered := rdy.snapshotComponents()
+	names := make(map[string]bool, len(registered))
+	for _, component := range registered {
+		names[component.Name()] = true
+	}
+
+	now := time.Now()
+
+	for i, component := range components {
+		if !names[component.Name()] {
+			continue
+		}
+
+		isReady := readiness[i]
+		wasReady, known := rdy.componentStates[component.Name()]
+		rdy.componentStates[component.Name()] = isReady
+
+		if known && wasReady != isReady {
+			rdy.pendingTransitions = append(rdy.pendingTransitions, ReadyEvent{Component: component.Name(), WasReady: wasReady, Ready: isReady, At: now})
+		}
+	}
+
+	allReady, known := rdy.overallState(registered)
+	if !known {
+		return
+	}
+
+	if !rdy.draining.Load() {
+		allReady = rdy.damp(allReady)
+	}
+
+	// A change of the drain mode is published even when the overall readiness does not change, since it also applies to the
+	// components
+	draining := rdy.draining.Load()
+	if rdy.readyKnown && (rdy.lastReady != allReady || rdy.lastDraining != draining) {
+		rdy.pendingTransitions = append(rdy.pendingTransitions, ReadyEvent{WasReady: rdy.lastReady, Ready: allReady, Draining: draining, At: now})
+	}
+	rdy.readyKnown = true
+	rdy.lastReady = allReady
+	rdy.lastDraining = draining
+}
+
+// overallState derives the overall readiness from the recorded states of the components. It is unknown while a component which
+// could make it change was never evaluated. Must be called while holding the transition mutex.
+func (rdy *ReadyCheck) overallState(components []ComponentCheck) (isReady bool, known bool) {
+	if rdy.draining.Load() {
+		return false, true
+	}
+
+	known = true
+	for _, component := range components {
+		isReady, evaluated := rdy.componentStates[component.Name()]
+		if !evaluated {
+			known = false
+		} else if !isReady && rdy.isCritical(component) {
+			return false, true
+		}
+	}
+
+	return known, known
+}
@@ -0,0 +1,378 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type transitionRecorder struct {
+	mutex      sync.Mutex
+	components []string
+	ready      []bool
+}
+
+func (r *transitionRecorder) onChange(component string, ready bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if ready {
+		r.components = append(r.components, component+":ready")
+	} else {
+		r.components = append(r.components, component+":not-ready")
+	}
+}
+
+func (r *transitionRecorder) onReadyChange(ready bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ready = append(r.ready, ready)
+}
+
+func (r *transitionRecorder) snapshot() ([]string, []bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.components...), append([]bool{}, r.ready...)
+}
+
+func Test_WhenComponentsChange_ShouldInvokeCallbacks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readycheck.OnChange(recorder.onChange)
+	readycheck.OnReadyChange(recorder.onReadyChange)
+
+	db := readycheck.RegisterPushComponent("db")
+	cache := readycheck.RegisterPushComponent("cache")
+
+	db.SetReady(true)
+	cache.SetReady(true)
+	cache.SetReady(true)
+	db.SetReady(false)
+	db.SetReady(true)
+	readycheck.SetDraining(true)
+
+	components, ready := recorder.snapshot()
+	assert.Equal([]string{"db:ready", "cache:ready", "db:not-ready", "db:ready"}, components)
+	assert.Equal([]bool{true, false, true, false}, ready)
+}
+
+func Test_WhenPulseExpires_ShouldInvokeCallbacks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readycheck.OnChange(recorder.onChange)
+
+	pulse := readycheck.RegisterPulseComponent("worker", 30*time.Millisecond)
+	pulse.RecordPulse()
+
+	assert.Eventually(func() bool {
+		components, _ := recorder.snapshot()
+		return len(components) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	components, _ := recorder.snapshot()
+	assert.Equal([]string{"worker:ready", "worker:not-ready"}, components)
+}
+
+func Test_WhenPollChanges_ShouldInvokeCallbacks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	changes := make(chan bool, 10)
+	readycheck.OnReadyChange(func(ready bool) {
+		changes <- ready
+	})
+
+	readycheck.RegisterPollComponent("db", func() bool {
+		return true
+	}, 10*time.Millisecond)
+
+	readycheck.StartPolling()
+	defer readycheck.StopPolling()
+
+	select {
+	case ready := <-changes:
+		assert.True(ready)
+	case <-time.After(time.Second):
+		assert.Fail("ready change should be notified")
+	}
+}
+
+func Test_WhenCallbackUpdatesComponent_ShouldDispatchInOrder(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+	dependent := readycheck.RegisterPushComponent("dependent")
+
+	recorder := &transitionRecorder{}
+	readycheck.OnChange(func(component string, ready bool) {
+		if component == "db" {
+			dependent.SetReady(ready)
+		}
+	})
+	readycheck.OnChange(recorder.onChange)
+
+	db.SetReady(true)
+
+	components, _ := recorder.snapshot()
+	assert.Equal([]string{"db:ready", "dependent:ready"}, components)
+}
+
+func Test_WhenSubscribed_ShouldReceiveReadyEvents(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+
+	first := readycheck.Subscribe()
+	second := readycheck.Subscribe()
+
+	before := time.Now()
+	db.SetReady(true)
+
+	for _, events := range []<-chan lifecycle.ReadyEvent{first, second} {
+		event := <-events
+		assert.Equal("db", event.Component)
+		assert.False(event.WasReady)
+		assert.True(event.Ready)
+		assert.False(event.At.Before(before))
+
+		event = <-events
+		assert.Equal("", event.Component, "overall readiness should change")
+		assert.True(event.Ready)
+	}
+
+	readycheck.Unsubscribe(first)
+	_, open := <-first
+	assert.False(open, "channel should be closed once unsubscribed")
+}
+
+func Test_WhenSubscriberIsSlow_ShouldNotBlock(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+	events := readycheck.Subscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			db.SetReady(i%2 == 0)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail("slow subscriber should not block the ready check")
+	}
+
+	assert.NotEmpty(events)
+}
+
+func Test_WhenComponentsBecomeReady_ShouldStopWaiting(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+	cache := readycheck.RegisterPushComponent("cache")
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		db.SetReady(true)
+		time.Sleep(20 * time.Millisecond)
+		cache.SetReady(true)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(readycheck.WaitUntilReady(ctx))
+	assert.True(readycheck.Ready())
+}
+
+func Test_WhenComponentsDoNotBecomeReady_ShouldStopWaitingWithContext(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("db")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(readycheck.WaitUntilReady(ctx), context.DeadlineExceeded)
+}
+
+func Test_WhenCustomCheckRecovers_ShouldStopWaiting(t *testing.T) {
+	assert := assert2.New(t)
+
+	clock := lifecycletest.NewClock(time.Now())
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{Clock: clock})
+	remoteReady := &atomic.Bool{}
+	readycheck.RegisterComponent("remote", toggleComponentCheck{name: "remote", ready: remoteReady})
+
+	done := make(chan error)
+	go func() {
+		done <- readycheck.WaitUntilReady(context.Background())
+	}()
+
+	<-clock.TimersCreated(1)
+	remoteReady.Store(true)
+	clock.Advance(lifecycle.DefaultRefreshInterval)
+
+	select {
+	case err := <-done:
+		assert.NoError(err)
+	case <-time.After(time.Second):
+		assert.Fail("recovered custom check should be detected while waiting")
+	}
+}
+
+func Test_WhenReadinessIsObserved_ShouldNotEvaluateComponents(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+
+	isReady, components := readycheck.ObservedReadiness()
+	assert.False(isReady)
+	assert.Equal(map[string]bool{"db": false}, components)
+
+	db.SetReady(true)
+
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("remote", countingComponentCheck{name: "remote", calls: calls})
+
+	isReady, components = readycheck.ObservedReadiness()
+	assert.True(isReady)
+	assert.Equal(map[string]bool{"db": true}, components, "the custom check was never observed")
+	assert.Zero(calls.Load(), "observing the readiness should not evaluate the checks")
+}
+
+type toggleComponentCheck struct {
+	name  string
+	ready *atomic.Bool
+}
+
+func (c toggleComponentCheck) Name() string {
+	return c.name
+}
+
+func (c toggleComponentCheck) Ready() bool {
+	return c.ready.Load()
+}
+
+func Test_WhenCustomCheckChanges_ShouldInvokeCallbacksOnNextChange(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{RefreshInterval: 10 * time.Millisecond})
+	recorder := &transitionRecorder{}
+	readycheck.OnChange(recorder.onChange)
+	readycheck.OnReadyChange(recorder.onReadyChange)
+
+	remoteReady := &atomic.Bool{}
+	remoteReady.Store(true)
+	readycheck.RegisterComponent("remote", toggleComponentCheck{name: "remote", ready: remoteReady})
+	db := readycheck.RegisterPushComponent("db")
+
+	db.SetReady(true)
+	assert.Eventually(func() bool {
+		_, ready := recorder.snapshot()
+		return len(ready) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	remoteReady.Store(false)
+	db.SetReady(true)
+	db.SetReady(false)
+
+	assert.Eventually(func() bool {
+		components, _ := recorder.snapshot()
+		return len(components) == 3
+	}, time.Second, 5*time.Millisecond)
+
+	components, ready := recorder.snapshot()
+	assert.Contains(components, "remote:not-ready")
+	assert.Equal([]bool{true, false}, ready)
+}
+
+func Test_WhenCustomCheckIsSlow_ShouldNotBlockNotifications(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.OnChange(func(string, bool) {})
+	readycheck.RegisterComponent("remote", slowComponentCheck{name: "remote", delay: 200 * time.Millisecond})
+	pulse := readycheck.RegisterPulseComponent("worker", time.Minute)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		pulse.RecordPulse()
+	}
+
+	assert.Less(time.Since(start), 100*time.Millisecond, "pulses should not wait for the custom check")
+}
+
+func Test_WhenNothingListens_ShouldNotEvaluateCustomChecks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("remote", countingComponentCheck{name: "remote", calls: calls})
+	pulse := readycheck.RegisterPulseComponent("worker", time.Minute)
+
+	for i := 0; i < 20; i++ {
+		pulse.RecordPulse()
+	}
+
+	assert.Zero(calls.Load(), "custom check should only be evaluated when its changes are observed")
+}
+
+func Test_WhenPulsesDoNotChangeReadiness_ShouldNotRefreshCustomChecks(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.OnChange(func(string, bool) {})
+	calls := &atomic.Int32{}
+	readycheck.RegisterComponent("remote", countingComponentCheck{name: "remote", calls: calls})
+	pulse := readycheck.RegisterPulseComponent("worker", time.Minute)
+
+	for i := 0; i < 2000; i++ {
+		pulse.RecordPulse()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(calls.Load(), int32(2), "custom check should be refreshed at most once per interval")
+}
+
+func Test_WhenDrainingWhileNotReady_ShouldPublishDrainMode(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	recorder := &transitionRecorder{}
+	readycheck.OnReadyChange(recorder.onReadyChange)
+	readycheck.RegisterPushComponent("db")
+	events := readycheck.Subscribe()
+
+	readycheck.SetDraining(true)
+
+	event := <-events
+	assert.Equal("", event.Component)
+	assert.False(event.WasReady)
+	assert.False(event.Ready)
+	assert.True(event.Draining)
+
+	_, ready := recorder.snapshot()
+	assert.Empty(ready, "overall readiness did not change")
+}
@@ -1,69 +1,293 @@
 package lifecycle
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// ReadyCheckOptions are options used in conjunction with the [ReadyCheck] type
+type ReadyCheckOptions struct {
+	// CheckTimeout is the time allocated to each component check. Checks are evaluated concurrently, and a check which does not
+	// complete in time is considered not ready.
+	//
+	// Default: 1s
+	CheckTimeout time.Duration
+	// CacheTTL is the time during which the readiness of a component check is reused by [ReadyCheck.Ready] and
+	// [ReadyCheck.Explain] instead of being evaluated again. Only custom [ComponentCheck] implementations are cached, since the
+	// built-in checks only report their last recorded state. Caching is disabled when zero.
+	//
+	// Default: 0
+	CacheTTL time.Duration
+	// CacheJitter is the maximum random duration added to the CacheTTL of each cached check, so that the checks are not all
+	// refreshed by the same probe
+	//
+	// Default: 10% of the CacheTTL
+	CacheJitter time.Duration
+	// HoldDown is the duration during which a change of the overall readiness must persist before [ReadyCheck.Ready] reports
+	// it, preventing load balancer churn when a dependency oscillates. The first time the components are ready and entering the
+	// drain mode are reported immediately. Changes are also reported to [ReadyCheck.OnReadyChange] and [ReadyCheck.Subscribe]
+	// once they persisted.
+	//
+	// Default: 0
+	HoldDown time.Duration
+	// HistorySize is the number of recent outcomes kept for each component, reported by [ReadyCheck.ComponentHistory]. The
+	// history is disabled when negative.
+	//
+	// Default: 10
+	HistorySize int
+	// RefreshInterval is the minimum time between two background evaluations of the [ComponentCheck] implementations which do
+	// not notify their changes. They are evaluated when the built-in checks change, and only while callbacks or subscribers are
+	// registered, see [ReadyCheck.OnChange]. [ReadyCheck.WaitUntilReady] also evaluates the readiness at this interval.
+	//
+	// Default: 1s
+	RefreshInterval time.Duration
+	// Clock paces the poll components and the systemd watchdog, and measures the HoldDown. Tests may replace it to advance time
+	// without sleeping.
+	//
+	// Default: SystemClock
+	Clock Clock
+}
+
+var DefaultCheckTimeout = 1 * time.Second
+
+var DefaultRefreshInterval = 1 * time.Second
+
 // ReadyCheck is an utility that allows you to record the readiness status of multiple components and report them
 // when necessary.
 type ReadyCheck struct {
 	componentsMutex *sync.RWMutex
 
+	options    ReadyCheckOptions
 	components []ComponentCheck
+	settings   map[string]checkSettings
+	histories  map[string]*checkHistory
+	draining   *atomic.Bool
+	drainLive  *atomic.Bool
+
+	runMutex *sync.Mutex
+	cancel   context.CancelFunc
+
+	cacheMutex *sync.Mutex
+	cache      map[string]cachedReadiness
+
+	dampingMutex *sync.Mutex
+	damping      readinessDamping
+
+	transitionMutex      *sync.Mutex
+	componentStates      map[string]bool
+	readyKnown           bool
+	lastReady            bool
+	lastDraining         bool
+	pendingTransitions   []ReadyEvent
+	dispatching          bool
+	refreshing           bool
+	refreshAgain         bool
+	refreshScheduled     bool
+	lastRefresh          time.Time
+	changeCallbacks      []func(component string, ready bool)
+	readyChangeCallbacks []func(ready bool)
+	subscribers          []chan ReadyEvent
 }
 
-// NewReadyCheck creates a new instance of [ReadyCheck]
-func NewReadyCheck() *ReadyCheck {
+// NewReadyCheckWithOptions creates a new instance of [ReadyCheck] with the given options
+func NewReadyCheckWithOptions(options ReadyCheckOptions) *ReadyCheck {
+	if options.CheckTimeout == 0 {
+		options.CheckTimeout = DefaultCheckTimeout
+	}
+
+	if options.HistorySize == 0 {
+		options.HistorySize = DefaultHistorySize
+	}
+
+	if options.CacheJitter == 0 {
+		options.CacheJitter = options.CacheTTL / 10
+	}
+
+	if options.RefreshInterval == 0 {
+		options.RefreshInterval = DefaultRefreshInterval
+	}
+
+	if options.Clock == nil {
+		options.Clock = SystemClock
+	}
+
 	return &ReadyCheck{
 		componentsMutex: &sync.RWMutex{},
+		options:         options,
 		components:      make([]ComponentCheck, 0),
+		settings:        make(map[string]checkSettings),
+		histories:       make(map[string]*checkHistory),
+		draining:        &atomic.Bool{},
+		drainLive:       &atomic.Bool{},
+
+		runMutex: &sync.Mutex{},
+
+		cacheMutex: &sync.Mutex{},
+		cache:      make(map[string]cachedReadiness),
+
+		dampingMutex: &sync.Mutex{},
+
+		transitionMutex: &sync.Mutex{},
+		componentStates: make(map[string]bool),
 	}
 }
 
-// StartPolling starts polling from poll components
+// NewReadyCheck creates a new instance of [ReadyCheck]. Default options will be used.
+func NewReadyCheck() *ReadyCheck {
+	return NewReadyCheckWithOptions(ReadyCheckOptions{})
+}
+
+// Start starts polling from poll components, and stops polling them once the given context is done or [ReadyCheck.Close] is
+// called. Calling Start again has no effect until the ReadyCheck is closed.
+func (rdy *ReadyCheck) Start(ctx context.Context) {
+	rdy.runMutex.Lock()
+	defer rdy.runMutex.Unlock()
+
+	if rdy.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	rdy.cancel = cancel
+
+	runs := rdy.startPolling()
+
+	// Only the polling started here is stopped, so that a context done after Close does not stop the polling of a later Start
+	go func() {
+		<-ctx.Done()
+		for _, run := range runs {
+			run.component.stopRun(run.ctx)
+		}
+	}()
+}
+
+// Close stops polling from poll components. It always returns nil, and allows the ReadyCheck to be used as an [io.Closer].
+func (rdy *ReadyCheck) Close() error {
+	rdy.runMutex.Lock()
+	if rdy.cancel != nil {
+		rdy.cancel()
+		rdy.cancel = nil
+	}
+	rdy.runMutex.Unlock()
+
+	rdy.StopPolling()
+
+	return nil
+}
+
+// pollRun is the polling of a poll component, identified by the context cancelled when it stops
+type pollRun struct {
+	component *PollComponentCheck
+	ctx       context.Context
+}
+
+// StartPolling starts polling from poll components, including the ones nested in a [CompositeComponentCheck]
 func (rdy *ReadyCheck) StartPolling() {
-	for _, component := range rdy.components {
-		if poll, ok := component.(*PollComponentCheck); ok {
-			go poll.Start()
+	rdy.startPolling()
+}
+
+// startPolling starts polling from the poll components which are not polled yet, and returns the polling it started
+func (rdy *ReadyCheck) startPolling() []pollRun {
+	runs := make([]pollRun, 0)
+
+	for _, poll := range pollComponents(rdy.snapshotComponents()) {
+		// Started before polling, so that stopping right after starting is not overridden
+		if ctx, started := poll.begin(); started {
+			runs = append(runs, pollRun{component: poll, ctx: ctx})
+			go poll.poll(ctx)
 		}
 	}
+
+	return runs
 }
 
 // StopPolling stops polling from poll components
 func (rdy *ReadyCheck) StopPolling() {
-	for _, component := range rdy.components {
-		if poll, ok := component.(*PollComponentCheck); ok {
-			poll.Stop()
-		}
+	for _, poll := range pollComponents(rdy.snapshotComponents()) {
+		poll.Stop()
 	}
 }
 
-// Ready returns true if all components are considered ready
+// SetDraining puts the ReadyCheck in drain mode. While draining, [ReadyCheck.Ready] returns false regardless of the components'
+// status, so that the application stops receiving new traffic.
+func (rdy *ReadyCheck) SetDraining(draining bool) {
+	if draining && !rdy.draining.Load() {
+		rdy.drainLive.Store(rdy.live())
+	}
+
+	rdy.draining.Store(draining)
+	rdy.reevaluate()
+	rdy.refreshCustomChecks()
+}
+
+// Draining returns true if the ReadyCheck is in drain mode
+func (rdy *ReadyCheck) Draining() bool {
+	return rdy.draining.Load()
+}
+
+// Ready returns true if all components are considered ready, and the ReadyCheck is not draining. When
+// [ReadyCheckOptions.HoldDown] is set, changes are reported once they persisted for the hold-down duration.
 func (rdy *ReadyCheck) Ready() bool {
-	rdy.componentsMutex.RLock()
-	defer rdy.componentsMutex.RUnlock()
+	return rdy.readyFor(nil)
+}
 
-	for _, component := range rdy.components {
-		isReady := component.Ready()
-		if !isReady {
-			return false
+// ReadyForTags returns true if all components tagged with at least one of the given tags are considered ready, and the
+// ReadyCheck is not draining. The hold-down only applies to the readiness of all components.
+func (rdy *ReadyCheck) ReadyForTags(tags ...string) bool {
+	return rdy.readyFor(tags)
+}
+
+func (rdy *ReadyCheck) readyFor(tags []string) bool {
+	if rdy.draining.Load() {
+		return false
+	}
+
+	components := rdy.selectComponents(tags)
+
+	return rdy.reportReadiness(tags, components, rdy.checkReadiness(components))
+}
+
+// reportReadiness returns the readiness to report given the readiness of each component. The hold-down only applies to the
+// readiness of all components.
+func (rdy *ReadyCheck) reportReadiness(tags []string, components []ComponentCheck, readiness []bool) bool {
+	if rdy.draining.Load() {
+		return false
+	}
+
+	isReady := true
+	for i, componentReady := range readiness {
+		if !componentReady && rdy.isCritical(components[i]) {
+			isReady = false
+			break
 		}
 	}
 
-	return true
+	if len(tags) > 0 {
+		return isReady
+	}
+
+	return rdy.damp(isReady)
 }
 
 // Explain returns a map detailling which component is considered ready or not
 func (rdy *ReadyCheck) Explain() map[string]bool {
-	rdy.componentsMutex.RLock()
-	defer rdy.componentsMutex.RUnlock()
+	return rdy.explain(rdy.snapshotComponents())
+}
+
+// ExplainForTags returns a map detailling which component tagged with at least one of the given tags is considered ready or not
+func (rdy *ReadyCheck) ExplainForTags(tags ...string) map[string]bool {
+	return rdy.explain(rdy.selectComponents(tags))
+}
 
-	explanation := make(map[string]bool, len(rdy.components))
+func (rdy *ReadyCheck) explain(components []ComponentCheck) map[string]bool {
+	readiness := rdy.checkReadiness(components)
 
-	for _, component := range rdy.components {
-		explanation[component.Name()] = component.Ready()
+	explanation := make(map[string]bool, len(components))
+
+	for i, component := range components {
+		explanation[component.Name()] = readiness[i]
 	}
 
 	return explanation
@@ -77,49 +301,184 @@ type ComponentCheck interface {
 }
 
 // RegisterPollComponent creates a new [PollComponentCheck] with the given [checkFn] and [pollDelay] and registers it
-func (rdy *ReadyCheck) RegisterPollComponent(name string, checkFn func() bool, pollDelay time.Duration) *PollComponentCheck {
-	pollComponent := &PollComponentCheck{
-		name:     name,
-		isReady:  &atomic.Bool{},
-		isActive: &atomic.Bool{},
+func (rdy *ReadyCheck) RegisterPollComponent(name string, checkFn func() bool, pollDelay time.Duration, opts ...CheckOption) *PollComponentCheck {
+	return rdy.RegisterPollComponentWithOptions(name, checkFn, PollOptions{Interval: pollDelay}, opts...)
+}
 
-		checkFn:   checkFn,
-		pollDelay: pollDelay,
-	}
+// RegisterPollComponentWithOptions creates a new [PollComponentCheck] with the given [checkFn] and options and registers it
+func (rdy *ReadyCheck) RegisterPollComponentWithOptions(name string, checkFn func() bool, options PollOptions, opts ...CheckOption) *PollComponentCheck {
+	pollComponent := newPollComponentCheck(name, func() (bool, error) {
+		return checkFn(), nil
+	}, options, rdy.options.Clock)
 
-	rdy.RegisterComponent(name, pollComponent)
+	rdy.RegisterComponent(name, pollComponent, opts...)
 
 	return pollComponent
 }
 
 // RegisterPushComponent creates a new [PushComponentCheck] and registers it
-func (rdy *ReadyCheck) RegisterPushComponent(name string) *PushComponentCheck {
-	pushComponent := &PushComponentCheck{
-		name:    name,
-		isReady: &atomic.Bool{},
-	}
+func (rdy *ReadyCheck) RegisterPushComponent(name string, opts ...CheckOption) *PushComponentCheck {
+	pushComponent := newPushComponentCheck(name)
 
-	rdy.RegisterComponent(name, pushComponent)
+	rdy.RegisterComponent(name, pushComponent, opts...)
 
 	return pushComponent
 }
 
+func newPushComponentCheck(name string) *PushComponentCheck {
+	return &PushComponentCheck{
+		name:      name,
+		isReady:   &atomic.Bool{},
+		degraded:  &atomic.Bool{},
+		checkedAt: &atomic.Pointer[time.Time]{},
+		outcome:   &atomic.Pointer[pushOutcome]{},
+	}
+}
+
 // RegisterPulseComponent creates a new [PulseComponentCheck] and registers it
-func (rdy *ReadyCheck) RegisterPulseComponent(name string, exp time.Duration) *PulseComponentCheck {
+func (rdy *ReadyCheck) RegisterPulseComponent(name string, exp time.Duration, opts ...CheckOption) *PulseComponentCheck {
+	return rdy.RegisterPulseComponentWithOptions(name, PulseOptions{Expiration: exp}, opts...)
+}
+
+// RegisterPulseComponentWithOptions creates a new [PulseComponentCheck] with the given options and registers it
+func (rdy *ReadyCheck) RegisterPulseComponentWithOptions(name string, options PulseOptions, opts ...CheckOption) *PulseComponentCheck {
+	if options.MinPulses <= 0 {
+		options.MinPulses = 1
+	}
+
+	if options.Window <= 0 {
+		options.Window = options.Expiration
+	}
+
 	pulseComponent := &PulseComponentCheck{
 		name:       name,
-		expiration: exp,
+		options:    options,
 		lastPulse:  &atomic.Pointer[time.Time]{},
+		pulseCount: &atomic.Uint64{},
+		loopExited: &atomic.Bool{},
+
+		pulseChanOnce:  &sync.Once{},
+		callbacksMutex: &sync.Mutex{},
+		pulsesMutex:    &sync.Mutex{},
+		timerMutex:     &sync.Mutex{},
 	}
 
-	rdy.RegisterComponent(name, pulseComponent)
+	rdy.RegisterComponent(name, pulseComponent, opts...)
 	return pulseComponent
 }
 
-// RegisterComponent registers any given [ComponentCheck] interface
-func (rdy *ReadyCheck) RegisterComponent(name string, component ComponentCheck) {
+// UnregisterComponent removes the components registered with the given name, and stops polling them. It returns false if no
+// component was registered with that name.
+func (rdy *ReadyCheck) UnregisterComponent(name string) bool {
+	return rdy.unregister(name, func(component ComponentCheck) bool {
+		return true
+	})
+}
+
+// unregisterCheck removes the given component check, leaving the other components registered with the same name. It returns
+// false if the check was not registered.
+func (rdy *ReadyCheck) unregisterCheck(check ComponentCheck) bool {
+	return rdy.unregister(check.Name(), func(component ComponentCheck) bool {
+		return component == check
+	})
+}
+
+// unregister removes the components registered with the given name which match, and stops polling them. The state kept for the
+// name is only discarded once no component is registered with it anymore.
+func (rdy *ReadyCheck) unregister(name string, match func(component ComponentCheck) bool) bool {
 	rdy.componentsMutex.Lock()
-	defer rdy.componentsMutex.Unlock()
+	removed := make([]ComponentCheck, 0)
+	kept := make([]ComponentCheck, 0, len(rdy.components))
+	remaining := false
+	for _, component := range rdy.components {
+		if component.Name() != name {
+			kept = append(kept, component)
+		} else if match(component) {
+			removed = append(removed, component)
+		} else {
+			kept = append(kept, component)
+			remaining = true
+		}
+	}
+	rdy.components = kept
+	if len(removed) > 0 && !remaining {
+		delete(rdy.settings, name)
+		delete(rdy.histories, name)
+	}
+	rdy.componentsMutex.Unlock()
+
+	if len(removed) == 0 {
+		return false
+	}
+
+	for _, component := range removed {
+		if notifier, ok := component.(interface{ clearNotify() }); ok {
+			notifier.clearNotify()
+		}
+
+		for _, poll := range pollComponents([]ComponentCheck{component}) {
+			poll.clearNotify()
+			poll.Stop()
+		}
+
+		if pulse, ok := component.(*PulseComponentCheck); ok {
+			pulse.stopExpiryTimer()
+		}
+	}
+
+	if !remaining {
+		rdy.transitionMutex.Lock()
+		delete(rdy.componentStates, name)
+		rdy.transitionMutex.Unlock()
+	}
+
+	rdy.invalidateCache(name)
+
+	rdy.reevaluate()
+
+	return true
+}
 
+// RegisterComponent registers any given [ComponentCheck] interface
+func (rdy *ReadyCheck) RegisterComponent(name string, component ComponentCheck, opts ...CheckOption) {
+	settings := checkSettings{}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	rdy.componentsMutex.Lock()
 	rdy.components = append(rdy.components, component)
+	rdy.settings[component.Name()] = settings
+	rdy.trackHistory(component)
+	rdy.componentsMutex.Unlock()
+
+	rdy.invalidateCache(component.Name())
+
+	if composite, ok := component.(*CompositeComponentCheck); ok {
+		composite.setCheckTimeout(rdy.options.CheckTimeout)
+
+		// The nested poll components refresh the composite check whenever their readiness changes
+		for _, poll := range pollComponents(composite.checks) {
+			poll.setNotify(rdy.refreshCustomChecks)
+		}
+	}
+
+	if notifier, ok := component.(interface{ setNotify(fn func()) }); ok {
+		notifier.setNotify(func() {
+			rdy.componentChanged(component)
+		})
+	}
+
+	if isNonBlocking(component) {
+		rdy.componentChanged(component)
+	} else {
+		rdy.reevaluate()
+		rdy.refreshCustomChecks()
+	}
+
+	for _, poll := range pollComponents([]ComponentCheck{component}) {
+		if poll.options.ImmediateCheck {
+			poll.checkNow()
+		}
+	}
 }
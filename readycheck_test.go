@@ -1,6 +1,8 @@
 package lifecycle_test
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -94,3 +96,124 @@ func Test_WhenComponentIsNotReady_ShouldExplain(t *testing.T) {
 		assert.True(componentReady, "component-3 should be ready")
 	}
 }
+
+func Test_WhenDraining_ShouldReturnNotReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	pushCheck := readycheck.RegisterPushComponent("component-1")
+	pushCheck.SetReady(true)
+
+	readycheck.SetDraining(true)
+	assert.False(readycheck.Ready(), "draining ready check should not be ready")
+	assert.Equal(map[string]bool{"component-1": true}, readycheck.Explain())
+
+	readycheck.SetDraining(false)
+	assert.True(readycheck.Ready())
+}
+
+func Test_WhenComponentIsUnregistered_ShouldNoLongerAffectReadiness(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterPushComponent("component-1").SetReady(true)
+	readycheck.RegisterPushComponent("tenant-1")
+
+	polls := atomic.Int32{}
+	readycheck.RegisterPollComponent("tenant-2", func() bool {
+		polls.Add(1)
+		return false
+	}, 10*time.Millisecond)
+
+	readycheck.StartPolling()
+	defer readycheck.StopPolling()
+
+	changes := make(chan bool, 1)
+	readycheck.OnReadyChange(func(ready bool) {
+		changes <- ready
+	})
+
+	assert.False(readycheck.Ready())
+
+	assert.True(readycheck.UnregisterComponent("tenant-1"))
+	assert.True(readycheck.UnregisterComponent("tenant-2"))
+	assert.False(readycheck.UnregisterComponent("unknown"))
+
+	assert.True(readycheck.Ready())
+	assert.Equal(map[string]bool{"component-1": true}, readycheck.Explain())
+	assert.True(<-changes, "overall readiness should change")
+
+	time.Sleep(30 * time.Millisecond)
+	pollsAfterUnregister := polls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(pollsAfterUnregister, polls.Load(), "unregistered component should no longer be polled")
+}
+
+func Test_WhenContextIsDone_ShouldStopPolling(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := atomic.Int32{}
+	readycheck.RegisterPollComponent("db", func() bool {
+		calls.Add(1)
+		return true
+	}, 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	readycheck.Start(ctx)
+	readycheck.Start(ctx)
+
+	assert.Eventually(readycheck.Ready, time.Second, time.Millisecond)
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	nbCalls := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(nbCalls, calls.Load(), "polling should have stopped")
+}
+
+func Test_WhenClosed_ShouldStopPolling(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := atomic.Int32{}
+	readycheck.RegisterPollComponent("db", func() bool {
+		calls.Add(1)
+		return true
+	}, 5*time.Millisecond)
+
+	readycheck.Start(context.Background())
+	assert.Eventually(readycheck.Ready, time.Second, time.Millisecond)
+
+	assert.NoError(readycheck.Close())
+	time.Sleep(10 * time.Millisecond)
+	nbCalls := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(nbCalls, calls.Load(), "polling should have stopped")
+}
+
+func Test_WhenStartedAgainAfterClose_ShouldKeepPolling(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	calls := atomic.Int32{}
+	readycheck.RegisterPollComponent("db", func() bool {
+		calls.Add(1)
+		return true
+	}, 5*time.Millisecond)
+
+	readycheck.Start(context.Background())
+	assert.NoError(readycheck.Close())
+
+	readycheck.Start(context.Background())
+	defer readycheck.Close()
+
+	time.Sleep(20 * time.Millisecond)
+	nbCalls := calls.Load()
+
+	assert.Eventually(func() bool {
+		return calls.Load() > nbCalls
+	}, time.Second, time.Millisecond, "polling of the new Start should not be stopped by the previous one")
+}
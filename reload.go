@@ -0,0 +1,155 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ReloadOptions are options used in conjunction with the [ReloadManager] type
+type ReloadOptions struct {
+	// Timeout duration after which the remaining components are considered non-responsive
+	//
+	// Default: 5s
+	Timeout time.Duration
+	// Signal is the OS Signal triggering a reload once the ReloadManager is attached to a GracefulShutdown
+	//
+	// Default: SIGHUP
+	Signal os.Signal
+}
+
+// ReloadManager is an utility that allows you to reload the configuration of different components of your application,
+// typically when a SIGHUP is received.
+type ReloadManager struct {
+	componentMutex *sync.RWMutex
+	reloadMutex    *sync.Mutex
+
+	options    ReloadOptions
+	components map[string]ReloadFn
+}
+
+// ReloadFn reloads a component. The context is done when the reload timeout expires.
+type ReloadFn func(ctx context.Context) error
+
+var (
+	DefaultReloadSignal os.Signal = syscall.SIGHUP
+
+	ErrReloadInProgress = errors.New("reload is already in progress")
+	ErrReloadTimeout    = errors.New("reload took too long to complete")
+)
+
+// NewReloadManagerWithOptions creates a new instance of [*ReloadManager] with the given options
+func NewReloadManagerWithOptions(options ReloadOptions) *ReloadManager {
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	if options.Signal == nil {
+		options.Signal = DefaultReloadSignal
+	}
+
+	return &ReloadManager{
+		componentMutex: &sync.RWMutex{},
+		reloadMutex:    &sync.Mutex{},
+
+		options:    options,
+		components: make(map[string]ReloadFn),
+	}
+}
+
+// NewReloadManager creates a new instance of [*ReloadManager]. Default options will be used.
+func NewReloadManager() *ReloadManager {
+	return NewReloadManagerWithOptions(ReloadOptions{})
+}
+
+// Register registers the reload function of a component. Registering the same component twice returns a
+// [ErrComponentAlreadyRegistered] error.
+func (rm *ReloadManager) Register(name string, reloadFn ReloadFn) error {
+	rm.componentMutex.Lock()
+	defer rm.componentMutex.Unlock()
+
+	if _, ok := rm.components[name]; ok {
+		return ErrComponentAlreadyRegistered
+	}
+
+	rm.components[name] = reloadFn
+
+	return nil
+}
+
+// Attach makes the GracefulShutdown trigger a reload when the configured [ReloadOptions.Signal] is received while waiting for
+// the shutdown. Reload errors are reported to [GracefulShutdownOptions.OnSignalError].
+func (rm *ReloadManager) Attach(gs *GracefulShutdown) {
+	gs.HandleSignal(rm.options.Signal, rm.Reload)
+}
+
+// Reload invokes the reload function of every component concurrently, and waits for them to complete within the configured
+// timeout. If any component fails to reload, a [ReloadError] is returned. Components which did not complete in time are reported
+// with a [ErrReloadTimeout] error.
+//
+// Invoking Reload while another reload is in progress returns a [ErrReloadInProgress] error.
+func (rm *ReloadManager) Reload(ctx context.Context) error {
+	if !rm.reloadMutex.TryLock() {
+		return ErrReloadInProgress
+	}
+	defer rm.reloadMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, rm.options.Timeout)
+	defer cancel()
+
+	rm.componentMutex.RLock()
+	components := make(map[string]ReloadFn, len(rm.components))
+	for name, reloadFn := range rm.components {
+		components[name] = reloadFn
+	}
+	rm.componentMutex.RUnlock()
+
+	results := make(chan componentResult, len(components))
+	for name, reloadFn := range components {
+		go func(name string, reloadFn ReloadFn) {
+			results <- componentResult{
+				name: name,
+				err:  callShutdownFn(ctx, reloadFn),
+			}
+		}(name, reloadFn)
+	}
+
+	componentErrors := make(map[string]error)
+
+	for len(components) > 0 {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				componentErrors[result.name] = result.err
+			}
+			delete(components, result.name)
+
+		case <-ctx.Done():
+			for name := range components {
+				componentErrors[name] = ErrReloadTimeout
+			}
+			components = nil
+		}
+	}
+
+	if len(componentErrors) == 0 {
+		return nil
+	}
+
+	return ReloadError{
+		ComponentErrors: componentErrors,
+	}
+}
+
+// ReloadError details errors by component
+type ReloadError struct {
+	ComponentErrors map[string]error
+}
+
+func (err ReloadError) Error() string {
+	return fmt.Sprintf("error while reloading (%+v)", err.ComponentErrors)
+}
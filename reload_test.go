@@ -0,0 +1,61 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenReloading_ShouldReloadAllComponents(t *testing.T) {
+	assert := assert2.New(t)
+	rm := lifecycle.NewReloadManager()
+
+	calls := atomic.Int32{}
+	reload := func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}
+
+	assert.NoError(rm.Register("config", reload))
+	assert.NoError(rm.Register("tls", reload))
+	assert.ErrorIs(rm.Register("tls", reload), lifecycle.ErrComponentAlreadyRegistered)
+
+	assert.NoError(rm.Reload(context.Background()))
+	assert.Equal(int32(2), calls.Load())
+}
+
+func Test_WhenReloadFails_ShouldAggregateErrors(t *testing.T) {
+	assert := assert2.New(t)
+	rm := lifecycle.NewReloadManagerWithOptions(lifecycle.ReloadOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	expectedErr := errors.New("invalid config")
+
+	_ = rm.Register("config", func(ctx context.Context) error {
+		return expectedErr
+	})
+	_ = rm.Register("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	_ = rm.Register("ok", func(ctx context.Context) error {
+		return nil
+	})
+
+	err := rm.Reload(context.Background())
+
+	reloadErr := lifecycle.ReloadError{}
+	if !assert.ErrorAs(err, &reloadErr) {
+		return
+	}
+
+	assert.Len(reloadErr.ComponentErrors, 2)
+	assert.ErrorIs(reloadErr.ComponentErrors["config"], expectedErr)
+	assert.ErrorIs(reloadErr.ComponentErrors["slow"], lifecycle.ErrReloadTimeout)
+}
@@ -0,0 +1,82 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RemoteOptions are options used in conjunction with [ReadyCheck.RegisterRemoteComponent]
+type RemoteOptions struct {
+	// Poll are the options of the poll component fetching the health endpoint
+	Poll PollOptions
+	// Timeout is the time allocated to each request
+	//
+	// Default: 5s
+	Timeout time.Duration
+	// Client is the client sending the requests
+	//
+	// Default: http.DefaultClient
+	Client *http.Client
+}
+
+// remoteHealth is the subset of the documents served by [ReadyCheck.Handler] and [ReadyCheck.HealthHandler] used to determine
+// the readiness of a remote service
+type remoteHealth struct {
+	Ready  *bool  `json:"ready"`
+	Status string `json:"status"`
+}
+
+// RegisterRemoteComponent registers a [PollComponentCheck] fetching the health endpoint of another service at the given URL,
+// so that the readiness of the service propagates to this ReadyCheck. The service is ready when the endpoint responds with a
+// 2xx status, unless the JSON document it serves reports it as not ready. The documents served by [ReadyCheck.Handler] and
+// [ReadyCheck.HealthHandler] are understood. Like other poll components, the component must be started with
+// [ReadyCheck.StartPolling] or [ReadyCheck.Start].
+func (rdy *ReadyCheck) RegisterRemoteComponent(name string, url string, options RemoteOptions, opts ...CheckOption) *PollComponentCheck {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultProbeTimeout
+	}
+
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+
+	return rdy.RegisterPollComponentWithOptions(name, func() bool {
+		return fetchRemoteHealth(options.Client, url, options.Timeout)
+	}, options.Poll, opts...)
+}
+
+func fetchRemoteHealth(client *http.Client, url string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil || res.StatusCode < 200 || res.StatusCode > 299 {
+		return false
+	}
+
+	// Endpoints which do not serve a JSON document are only judged by their status code
+	health := remoteHealth{}
+	if json.Unmarshal(body, &health) != nil {
+		return true
+	}
+
+	if health.Ready != nil && !*health.Ready {
+		return false
+	}
+
+	return health.Status != StatusNotReady.String() && health.Status != HealthStatusFail
+}
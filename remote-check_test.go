@@ -0,0 +1,62 @@
+package lifecycle_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenRemoteServiceIsReady_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	remote := lifecycle.NewReadyCheck()
+	remote.RegisterPushComponent("db").SetReady(true)
+
+	ready := httptest.NewServer(remote.Handler())
+	defer ready.Close()
+	health := httptest.NewServer(remote.HealthHandler())
+	defer health.Close()
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer plain.Close()
+
+	readycheck := lifecycle.NewReadyCheck()
+	options := lifecycle.RemoteOptions{Poll: lifecycle.PollOptions{Interval: time.Hour, ImmediateCheck: true}}
+	readycheck.RegisterRemoteComponent("ready", ready.URL, options)
+	readycheck.RegisterRemoteComponent("health", health.URL, options)
+	readycheck.RegisterRemoteComponent("plain", plain.URL, options)
+
+	assert.Equal(map[string]bool{"ready": true, "health": true, "plain": true}, readycheck.Explain())
+}
+
+func Test_WhenRemoteServiceIsNotReady_ShouldNotBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	remote := lifecycle.NewReadyCheck()
+	remote.RegisterPushComponent("db")
+
+	ready := httptest.NewServer(remote.Handler())
+	defer ready.Close()
+	reportingFailure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"fail"}`))
+	}))
+	defer reportingFailure.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	readycheck := lifecycle.NewReadyCheck()
+	options := lifecycle.RemoteOptions{
+		Poll:    lifecycle.PollOptions{Interval: time.Hour, ImmediateCheck: true},
+		Timeout: time.Second,
+	}
+	readycheck.RegisterRemoteComponent("ready", ready.URL, options)
+	readycheck.RegisterRemoteComponent("failure", reportingFailure.URL, options)
+	readycheck.RegisterRemoteComponent("unreachable", unreachable.URL, options)
+
+	assert.Equal(map[string]bool{"ready": false, "failure": false, "unreachable": false}, readycheck.Explain())
+}
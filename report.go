@@ -0,0 +1,107 @@
+package lifecycle
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ComponentOutcome is how a component's shutdown ended
+type ComponentOutcome int
+
+const (
+	// OutcomeSucceeded means the component shutdown without error
+	OutcomeSucceeded ComponentOutcome = iota
+	// OutcomeFailed means the component reported an error, or panicked
+	OutcomeFailed
+	// OutcomeTimedOut means the component did not complete its shutdown in time
+	OutcomeTimedOut
+)
+
+// String returns the name of the outcome
+func (o ComponentOutcome) String() string {
+	switch o {
+	case OutcomeSucceeded:
+		return "succeeded"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomeTimedOut:
+		return "timed out"
+	default:
+		return "unknown"
+	}
+}
+
+// outcomeOf returns the outcome matching the error reported by a component
+func outcomeOf(err error) ComponentOutcome {
+	switch {
+	case err == nil:
+		return OutcomeSucceeded
+	case errors.Is(err, ErrShutdownTimeout):
+		return OutcomeTimedOut
+	default:
+		return OutcomeFailed
+	}
+}
+
+// ComponentReport details how a single component behaved during the shutdown
+type ComponentReport struct {
+	// Outcome is how the component's shutdown ended
+	Outcome ComponentOutcome
+	// Err is the error reported by the component, nil when it succeeded
+	Err error
+	// Duration is the time the component took to shutdown. See [ShutdownReport.Durations].
+	Duration time.Duration
+}
+
+// ShutdownReport details how the components behaved during the shutdown
+type ShutdownReport struct {
+	// Components details the outcome of every component, including the ones which succeeded
+	Components map[string]ComponentReport
+	// Durations is the time each component took to shutdown, measured from the moment it was signaled. Components which timed out
+	// report the time they were given, and components which were never signaled report a zero duration.
+	Durations map[string]time.Duration
+	// Leaks lists the goroutines suspected to have leaked, sorted by ID. It is only populated when
+	// [GracefulShutdownOptions.DetectGoroutineLeaks] is enabled.
+	Leaks []GoroutineLeak
+}
+
+// Succeeded returns the sorted names of the components which shutdown without error
+func (report ShutdownReport) Succeeded() []string {
+	return report.withOutcome(OutcomeSucceeded)
+}
+
+// Failed returns the sorted names of the components which reported an error
+func (report ShutdownReport) Failed() []string {
+	return report.withOutcome(OutcomeFailed)
+}
+
+// TimedOut returns the sorted names of the components which did not shutdown in time
+func (report ShutdownReport) TimedOut() []string {
+	return report.withOutcome(OutcomeTimedOut)
+}
+
+func (report ShutdownReport) withOutcome(outcome ComponentOutcome) []string {
+	names := make([]string, 0, len(report.Components))
+	for name, component := range report.Components {
+		if component.Outcome == outcome {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Report returns the [ShutdownReport] of the last shutdown. It is also available through [ShutdownError.Report] when the
+// shutdown failed.
+func (gs *GracefulShutdown) Report() ShutdownReport {
+	return gs.progressTracker.report()
+}
+
+// ShutdownWithReport triggers the graceful shutdown process, like [GracefulShutdown.Shutdown], and returns the [ShutdownReport]
+// of every component along with the error.
+func (gs *GracefulShutdown) ShutdownWithReport() (ShutdownReport, error) {
+	err := gs.Shutdown()
+	return gs.Report(), err
+}
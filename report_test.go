@@ -0,0 +1,74 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenShutdownSucceeds_ShouldReportDurations(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	CreateSuccessComponent(gs, "fast", 0)
+	CreateSuccessComponent(gs, "slow", 150*time.Millisecond)
+
+	assert.NoError(gs.Shutdown())
+
+	report := gs.Report()
+	assert.Len(report.Durations, 2)
+	assert.Less(report.Durations["fast"], 100*time.Millisecond)
+	assert.GreaterOrEqual(report.Durations["slow"], 150*time.Millisecond)
+}
+
+func Test_WhenShutdownTimesOut_ShouldReportDurationsInError(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	CreateSuccessComponent(gs, "fast", 0)
+	CreateSuccessComponent(gs, "hung", time.Second)
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if !assert.ErrorAs(err, &shutdownErr) {
+		return
+	}
+
+	assert.Contains(shutdownErr.Report.Durations, "fast")
+	assert.GreaterOrEqual(shutdownErr.Report.Durations["hung"], 100*time.Millisecond)
+}
+
+func Test_WhenShutdownCompletes_ShouldReportOutcomeOfEveryComponent(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	failure := errors.New("failed to close")
+	CreateSuccessComponent(gs, "fast", 0)
+	CreateSuccessComponent(gs, "hung", time.Second)
+	assert.NoError(gs.RegisterComponentWithFn("broken", func() error {
+		return failure
+	}))
+
+	report, err := gs.ShutdownWithReport()
+	assert.Error(err)
+
+	assert.Equal([]string{"fast"}, report.Succeeded())
+	assert.Equal([]string{"broken"}, report.Failed())
+	assert.Equal([]string{"hung"}, report.TimedOut())
+
+	assert.Equal(lifecycle.OutcomeSucceeded, report.Components["fast"].Outcome)
+	assert.NoError(report.Components["fast"].Err)
+	assert.ErrorIs(report.Components["broken"].Err, failure)
+	assert.ErrorIs(report.Components["hung"].Err, lifecycle.ErrShutdownTimeout)
+	assert.GreaterOrEqual(report.Components["hung"].Duration, 100*time.Millisecond)
+	assert.Equal("timed out", report.Components["hung"].Outcome.String())
+}
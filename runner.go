@@ -0,0 +1,107 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrCriticalRunnerExited = errors.New("critical runner exited before the shutdown was requested")
+
+// Runner is a component which runs until its context is cancelled
+type Runner interface {
+	// Run blocks until the context is cancelled, or until the runner fails. Returning once the context is cancelled is considered
+	// a graceful shutdown.
+	Run(ctx context.Context) error
+}
+
+// RunnerFunc is a function implementing the [Runner] interface
+type RunnerFunc func(ctx context.Context) error
+
+// Run invokes the function
+func (fn RunnerFunc) Run(ctx context.Context) error {
+	return fn(ctx)
+}
+
+// RunnerError is the shutdown reason reported when a runner failed before the shutdown was requested
+type RunnerError struct {
+	// Name is the name of the runner component
+	Name string
+	// Err is the error returned by the runner
+	Err error
+}
+
+func (err RunnerError) Error() string {
+	return fmt.Sprintf("runner %s failed: %s", err.Name, err.Err)
+}
+
+func (err RunnerError) Unwrap() error {
+	return err.Err
+}
+
+// RegisterRunner registers a [Runner] as a component and starts it immediately in its own goroutine. When the component is
+// signaled, the runner's context is cancelled, and the component is considered shutdown once Run returns. A [context.Canceled]
+// error returned at that point is not reported as a failure.
+//
+// If the runner fails before the shutdown is requested, the failure is considered fatal: the shutdown is triggered with a
+// [RunnerError] as its reason, and the error is reported as the component's error.
+func (gs *GracefulShutdown) RegisterRunner(name string, runner Runner, opts ...ComponentOption) error {
+	return gs.registerRunner(name, runner, false, opts)
+}
+
+// Go runs the function in its own goroutine as a [Runner] component, like an errgroup would. The context given to the function
+// is cancelled when the component is signaled. If the function returns an error before the shutdown is requested, the
+// AppContext is cancelled with a [RunnerError] as its cause and the whole application is shutdown. See
+// [GracefulShutdown.RegisterRunner].
+func (gs *GracefulShutdown) Go(name string, fn func(ctx context.Context) error, opts ...ComponentOption) error {
+	return gs.RegisterRunner(name, RunnerFunc(fn), opts...)
+}
+
+// RegisterCritical registers a [Runner] like [GracefulShutdown.RegisterRunner], but any exit of the runner before the shutdown
+// is requested is considered fatal, including when it returns no error. The shutdown is then triggered with a [RunnerError] as
+// its reason, wrapping [ErrCriticalRunnerExited] when the runner returned no error, so the application does not keep running
+// without it.
+func (gs *GracefulShutdown) RegisterCritical(name string, runner Runner, opts ...ComponentOption) error {
+	return gs.registerRunner(name, runner, true, opts)
+}
+
+func (gs *GracefulShutdown) registerRunner(name string, runner Runner, critical bool, opts []ComponentOption) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	exited := make(chan error, 1)
+
+	err := gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		cancel()
+
+		select {
+		case err := <-exited:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+
+			return err
+		case <-ctx.Done():
+			return ErrShutdownTimeout
+		}
+	}, opts)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	go func() {
+		err := callShutdownFn(runCtx, runner.Run)
+
+		if runCtx.Err() == nil {
+			switch {
+			case err != nil:
+				gs.requestShutdown(RunnerError{Name: name, Err: err})
+			case critical:
+				gs.requestShutdown(RunnerError{Name: name, Err: ErrCriticalRunnerExited})
+			}
+		}
+
+		exited <- err
+	}()
+
+	return nil
+}
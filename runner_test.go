@@ -0,0 +1,220 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenRunnerIsRegistered_ShouldRunUntilShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	running := atomic.Bool{}
+	err := gs.RegisterRunner("consumer", lifecycle.RunnerFunc(func(ctx context.Context) error {
+		running.Store(true)
+		<-ctx.Done()
+		running.Store(false)
+
+		return ctx.Err()
+	}))
+	assert.NoError(err)
+
+	assert.Eventually(running.Load, time.Second, 10*time.Millisecond, "runner should be started immediately")
+
+	assert.NoError(gs.Shutdown())
+	assert.False(running.Load(), "runner should be stopped")
+}
+
+func Test_WhenRunnerFails_ShouldTriggerShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("connection lost")
+	fail := make(chan struct{})
+
+	_ = gs.RegisterRunner("consumer", lifecycle.RunnerFunc(func(ctx context.Context) error {
+		<-fail
+		return expectedErr
+	}))
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	time.Sleep(25 * time.Millisecond)
+	close(fail)
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(time.Second):
+		assert.Fail("runner failure should trigger the shutdown")
+		return
+	}
+
+	runnerErr := lifecycle.RunnerError{}
+	if assert.ErrorAs(gs.ShutdownReason(), &runnerErr) {
+		assert.Equal("consumer", runnerErr.Name)
+		assert.ErrorIs(runnerErr, expectedErr)
+	}
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.ErrorIs(shutdownErr.ComponentErrors["consumer"], expectedErr)
+	}
+}
+
+func Test_WhenCriticalRunnerExitsWithoutError_ShouldTriggerShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	exit := make(chan struct{})
+	assert.NoError(gs.RegisterCritical("leader-election", lifecycle.RunnerFunc(func(ctx context.Context) error {
+		<-exit
+		return nil
+	})))
+
+	otherStopped := atomic.Bool{}
+	assert.NoError(gs.RegisterComponentWithFn("http-server", func() error {
+		otherStopped.Store(true)
+		return nil
+	}))
+
+	close(exit)
+
+	assert.Eventually(func() bool {
+		return gs.State() == lifecycle.StateStopped
+	}, time.Second, 10*time.Millisecond, "critical runner exit should trigger the shutdown")
+
+	runnerErr := lifecycle.RunnerError{}
+	if assert.ErrorAs(gs.ShutdownReason(), &runnerErr) {
+		assert.Equal("leader-election", runnerErr.Name)
+		assert.ErrorIs(runnerErr, lifecycle.ErrCriticalRunnerExited)
+	}
+	assert.True(otherStopped.Load(), "other components should be drained")
+}
+
+func Test_WhenCriticalRunnerExitsBeforeWaitingForShutdown_ShouldReportTheShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.NoError(gs.RegisterCritical("leader-election", lifecycle.RunnerFunc(func(ctx context.Context) error {
+		return nil
+	})))
+
+	<-gs.AppContext().Done()
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(err)
+	case <-time.After(time.Second):
+		assert.Fail("WaitForShutdown should report the shutdown triggered by the runner")
+		return
+	}
+
+	runnerErr := lifecycle.RunnerError{}
+	if assert.ErrorAs(gs.ShutdownReason(), &runnerErr) {
+		assert.ErrorIs(runnerErr, lifecycle.ErrCriticalRunnerExited)
+	}
+}
+
+func Test_WhenRunnerExitsWithoutError_ShouldNotTriggerShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	exited := make(chan struct{})
+	assert.NoError(gs.RegisterRunner("migration", lifecycle.RunnerFunc(func(ctx context.Context) error {
+		defer close(exited)
+		return nil
+	})))
+
+	<-exited
+	time.Sleep(25 * time.Millisecond)
+
+	assert.NoError(gs.AppContext().Err())
+}
+
+func Test_WhenGoFunctionFails_ShouldCancelAppContextWithCause(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("listener closed")
+	assert.NoError(gs.Go("http-server", func(ctx context.Context) error {
+		return expectedErr
+	}))
+
+	select {
+	case <-gs.AppContext().Done():
+	case <-time.After(time.Second):
+		assert.Fail("AppContext should be cancelled")
+		return
+	}
+
+	runnerErr := lifecycle.RunnerError{}
+	if assert.ErrorAs(context.Cause(gs.AppContext()), &runnerErr) {
+		assert.Equal("http-server", runnerErr.Name)
+		assert.ErrorIs(runnerErr, expectedErr)
+	}
+}
+
+func Test_WhenGoFunctionFailsBeforeWaitingForShutdown_ShouldReportTheFailure(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("listener closed")
+	assert.NoError(gs.Go("http-server", func(ctx context.Context) error {
+		return expectedErr
+	}))
+
+	<-gs.AppContext().Done()
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	select {
+	case err := <-done:
+		shutdownErr := lifecycle.ShutdownError{}
+		if assert.ErrorAs(err, &shutdownErr) {
+			assert.ErrorIs(shutdownErr.ComponentErrors["http-server"], expectedErr)
+		}
+	case <-time.After(time.Second):
+		assert.Fail("WaitForShutdown should report the shutdown triggered by the failure")
+	}
+}
+
+func Test_WhenRunnerDoesNotStopInTime_ShouldReportTimeout(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout:      50 * time.Millisecond,
+		PollDuration: 10 * time.Millisecond,
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	assert.NoError(gs.Go("consumer", func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	err := gs.Shutdown()
+
+	shutdownErr := lifecycle.ShutdownError{}
+	if assert.ErrorAs(err, &shutdownErr) {
+		assert.Equal(lifecycle.ErrShutdownTimeout, shutdownErr.ComponentErrors["consumer"])
+	}
+}
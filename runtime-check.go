@@ -0,0 +1,103 @@
+package lifecycle
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+var ErrRuntimePressure = errors.New("runtime usage is above the threshold")
+
+// RuntimeOptions are options used in conjunction with the [RuntimeComponentCheck] type. Thresholds which are zero are not
+// enforced.
+type RuntimeOptions struct {
+	// MaxHeapInUse is the size of the heap in use, in bytes, above which the component is not ready
+	MaxHeapInUse uint64
+	// DegradedHeapInUse is the size of the heap in use, in bytes, above which the component is degraded
+	DegradedHeapInUse uint64
+	// MaxMemory is the memory obtained from the OS and not yet released, in bytes, above which the component is not ready. It
+	// approximates the resident set size of the process.
+	MaxMemory uint64
+	// DegradedMemory is the memory obtained from the OS and not yet released, in bytes, above which the component is degraded
+	DegradedMemory uint64
+	// MaxGoroutines is the number of goroutines above which the component is not ready
+	MaxGoroutines int
+	// DegradedGoroutines is the number of goroutines above which the component is degraded
+	DegradedGoroutines int
+}
+
+// RuntimeComponentCheck is a component check reporting the memory and goroutines used by the process, so that a leaking
+// application reports itself before running out of memory
+type RuntimeComponentCheck struct {
+	name    string
+	options RuntimeOptions
+}
+
+// RuntimeCheck creates a [RuntimeComponentCheck] measuring the runtime usage each time it is checked
+func RuntimeCheck(name string, options RuntimeOptions) ComponentCheck {
+	return &RuntimeComponentCheck{
+		name:    name,
+		options: options,
+	}
+}
+
+// Name is the name of the component being checked for
+func (component *RuntimeComponentCheck) Name() string {
+	return component.name
+}
+
+// Ready returns true if the runtime usage is below the not ready thresholds
+func (component *RuntimeComponentCheck) Ready() bool {
+	return component.Status() != StatusNotReady
+}
+
+// Status returns [StatusNotReady] or [StatusDegraded] when the runtime usage is above their thresholds
+func (component *RuntimeComponentCheck) Status() Status {
+	status, _ := component.check()
+
+	return status
+}
+
+// Result returns the readiness of the component, and the memory and goroutines used
+func (component *RuntimeComponentCheck) Result() CheckResult {
+	status, result := component.check()
+	result.Ready = status != StatusNotReady
+
+	return result
+}
+
+func (component *RuntimeComponentCheck) check() (Status, CheckResult) {
+	memStats := runtime.MemStats{}
+	runtime.ReadMemStats(&memStats)
+
+	heapInUse := memStats.HeapInuse
+	memory := memStats.Sys - memStats.HeapReleased
+	goroutines := runtime.NumGoroutine()
+
+	result := CheckResult{
+		CheckedAt: time.Now(),
+		Details: map[string]string{
+			"heapInUse":  strconv.FormatUint(heapInUse, 10),
+			"memory":     strconv.FormatUint(memory, 10),
+			"goroutines": strconv.Itoa(goroutines),
+		},
+	}
+
+	options := component.options
+	switch {
+	case isAbove(heapInUse, options.MaxHeapInUse) || isAbove(memory, options.MaxMemory) ||
+		isAbove(uint64(goroutines), uint64(options.MaxGoroutines)):
+		result.Err = ErrRuntimePressure
+		return StatusNotReady, result
+	case isAbove(heapInUse, options.DegradedHeapInUse) || isAbove(memory, options.DegradedMemory) ||
+		isAbove(uint64(goroutines), uint64(options.DegradedGoroutines)):
+		return StatusDegraded, result
+	default:
+		return StatusReady, result
+	}
+}
+
+func isAbove(value uint64, threshold uint64) bool {
+	return threshold > 0 && value > threshold
+}
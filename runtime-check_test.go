@@ -0,0 +1,45 @@
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenRuntimeUsageIsBelowThresholds_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("runtime", lifecycle.RuntimeCheck("runtime", lifecycle.RuntimeOptions{
+		MaxHeapInUse:  1 << 40,
+		MaxMemory:     1 << 40,
+		MaxGoroutines: 1_000_000,
+	}))
+
+	assert.Equal(lifecycle.StatusReady, readycheck.Status())
+
+	result := readycheck.ExplainDetailed()["runtime"]
+	assert.True(result.Ready)
+	assert.Contains(result.Details, "heapInUse")
+	assert.Contains(result.Details, "memory")
+	assert.Contains(result.Details, "goroutines")
+}
+
+func Test_WhenRuntimeUsageIsAboveThresholds_ShouldReportStatus(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("goroutines", lifecycle.RuntimeCheck("goroutines", lifecycle.RuntimeOptions{
+		DegradedGoroutines: 1,
+	}))
+	readycheck.RegisterComponent("heap", lifecycle.RuntimeCheck("heap", lifecycle.RuntimeOptions{
+		MaxHeapInUse: 1,
+	}))
+
+	assert.Equal(map[string]lifecycle.Status{
+		"goroutines": lifecycle.StatusDegraded,
+		"heap":       lifecycle.StatusNotReady,
+	}, readycheck.ExplainStatus())
+	assert.ErrorIs(readycheck.ExplainDetailed()["heap"].Err, lifecycle.ErrRuntimePressure)
+}
@@ -0,0 +1,39 @@
+package lifecycle
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ShutdownAt triggers the shutdown once the given time is reached, with [ErrScheduledShutdown] as its reason. Scheduling is
+// cancelled when the shutdown occurs beforehand.
+func (gs *GracefulShutdown) ShutdownAt(at time.Time) {
+	gs.scheduleShutdown(at.Sub(gs.options.Clock.Now()))
+}
+
+// scheduleMaxUptime schedules the shutdown after the MaxUptime, plus a random part of the jitter
+func (gs *GracefulShutdown) scheduleMaxUptime() {
+	if gs.options.MaxUptime <= 0 {
+		return
+	}
+
+	delay := gs.options.MaxUptime
+	if gs.options.MaxUptimeJitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(gs.options.MaxUptimeJitter)))
+	}
+
+	gs.scheduleShutdown(delay)
+}
+
+func (gs *GracefulShutdown) scheduleShutdown(delay time.Duration) {
+	appCtx := gs.AppContext()
+	elapsed := gs.options.Clock.After(delay)
+
+	go func() {
+		select {
+		case <-elapsed:
+			gs.requestShutdown(ErrScheduledShutdown)
+		case <-appCtx.Done():
+		}
+	}()
+}
@@ -0,0 +1,57 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenMaxUptimeIsReached_ShouldTriggerShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	clock := lifecycletest.NewClock(time.Now())
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Clock:           clock,
+		MaxUptime:       24 * time.Hour,
+		MaxUptimeJitter: time.Hour,
+	})
+
+	clock.Advance(24*time.Hour - time.Second)
+	assert.NoError(gs.AppContext().Err(), "shutdown should not be triggered before the MaxUptime")
+
+	clock.Advance(time.Hour)
+
+	select {
+	case <-gs.AppContext().Done():
+	case <-time.After(time.Second):
+		assert.Fail("shutdown should be triggered once the MaxUptime and its jitter elapsed")
+		return
+	}
+
+	assert.ErrorIs(gs.ShutdownReason(), lifecycle.ErrScheduledShutdown)
+}
+
+func Test_WhenShutdownAtIsReached_ShouldTriggerShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	clock := lifecycletest.NewClock(time.Now())
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Clock: clock,
+	})
+
+	gs.ShutdownAt(clock.Now().Add(time.Minute))
+	clock.Advance(time.Minute)
+
+	select {
+	case <-gs.AppContext().Done():
+	case <-time.After(time.Second):
+		assert.Fail("shutdown should be triggered at the scheduled time")
+		return
+	}
+
+	assert.ErrorIs(gs.ShutdownReason(), lifecycle.ErrScheduledShutdown)
+}
@@ -0,0 +1,75 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+)
+
+// Starter is implemented by services which need to be started
+type Starter interface {
+	// Start starts the service. It must return once the service is started.
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by services which need to be stopped
+type Stopper interface {
+	// Stop stops the service. The context is done when the shutdown timeout expires.
+	Stop(ctx context.Context) error
+}
+
+var ErrNotAService = errors.New("service implements neither Start(ctx) error nor Stop(ctx) error")
+
+// RegisterService registers any value implementing [Starter], [Stopper], or both. The service is started immediately with the
+// AppContext, and stopped as a component when signaled. If the service fails to start, it is not registered.
+//
+// A value implementing neither interface causes a [ErrNotAService] error.
+func (gs *GracefulShutdown) RegisterService(name string, svc any, opts ...ComponentOption) error {
+	starter, isStarter := svc.(Starter)
+	stopper, isStopper := svc.(Stopper)
+
+	if !isStarter && !isStopper {
+		return ErrNotAService
+	}
+
+	if isStopper {
+		err := gs.registerComponentFn(name, DefaultPhase, stopper.Stop, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isStarter {
+		err := starter.Start(gs.AppContext())
+		if err != nil {
+			if isStopper {
+				_ = gs.UnregisterComponent(name)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterService registers any value implementing [Starter], [Stopper], or both. The service is started with the app, and
+// stopped when the app shuts down or fails to start.
+//
+// A value implementing neither interface causes a [ErrNotAService] error.
+func (app *App) RegisterService(name string, svc any) error {
+	component := AppComponent{}
+
+	if starter, ok := svc.(Starter); ok {
+		component.Start = starter.Start
+	}
+
+	if stopper, ok := svc.(Stopper); ok {
+		component.Stop = stopper.Stop
+	}
+
+	if component.Start == nil && component.Stop == nil {
+		return ErrNotAService
+	}
+
+	return app.Register(name, component)
+}
@@ -0,0 +1,85 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	calls    []string
+	startErr error
+}
+
+func (s *fakeService) Start(ctx context.Context) error {
+	s.calls = append(s.calls, "Start")
+	return s.startErr
+}
+
+func (s *fakeService) Stop(ctx context.Context) error {
+	s.calls = append(s.calls, "Stop")
+	return nil
+}
+
+type fakeStopOnlyService struct {
+	stopped bool
+}
+
+func (s *fakeStopOnlyService) Stop(ctx context.Context) error {
+	s.stopped = true
+	return nil
+}
+
+func Test_WhenServiceIsRegistered_ShouldStartAndStopIt(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	svc := &fakeService{}
+	assert.NoError(gs.RegisterService("service", svc))
+	assert.Equal([]string{"Start"}, svc.calls)
+
+	stopOnly := &fakeStopOnlyService{}
+	assert.NoError(gs.RegisterService("stop-only", stopOnly))
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal([]string{"Start", "Stop"}, svc.calls)
+	assert.True(stopOnly.stopped)
+}
+
+func Test_WhenServiceFailsToStart_ShouldNotRegisterIt(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	expectedErr := errors.New("port already in use")
+	svc := &fakeService{startErr: expectedErr}
+
+	assert.ErrorIs(gs.RegisterService("service", svc), expectedErr)
+	assert.Empty(gs.RegisteredComponents())
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal([]string{"Start"}, svc.calls, "service should not be stopped")
+}
+
+func Test_WhenValueIsNotAService_ShouldReturnError(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.ErrorIs(gs.RegisterService("service", struct{}{}), lifecycle.ErrNotAService)
+	assert.ErrorIs(lifecycle.NewApp(context.Background()).RegisterService("service", struct{}{}), lifecycle.ErrNotAService)
+}
+
+func Test_WhenServiceIsRegisteredInApp_ShouldStartWithTheApp(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	svc := &fakeService{}
+	assert.NoError(app.RegisterService("service", svc))
+	assert.Empty(svc.calls, "service should start with the app")
+
+	assert.NoError(app.Start(context.Background()))
+	assert.NoError(app.Shutdown())
+	assert.Equal([]string{"Start", "Stop"}, svc.calls)
+}
@@ -0,0 +1,281 @@
+package lifecycle
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// drainSnapshot is the state of the registered components at the moment the drain begins
+type drainSnapshot struct {
+	components map[string]*component
+	groups     map[string]GroupOptions
+}
+
+// drain signals the components of the snapshot in order, and waits for them to complete their shutdown
+func (gs *GracefulShutdown) drain(ctx context.Context, snapshot drainSnapshot) error {
+	componentErrors := make(map[string]error)
+
+	waitingComponents := make(map[string]*component, len(snapshot.components))
+	componentNames := make([]string, 0, len(snapshot.components))
+	for componentName, c := range snapshot.components {
+		waitingComponents[componentName] = c
+		componentNames = append(componentNames, componentName)
+	}
+
+	// Components are considered in registration order, so the ones signaled first are predictable when the concurrency is limited
+	ordered := make([]*component, 0, len(snapshot.components))
+	for _, c := range snapshot.components {
+		ordered = append(ordered, c)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].order < ordered[j].order
+	})
+
+	gs.progressTracker.start(componentNames)
+
+	remainingComponents := make(map[string]*component, len(snapshot.components))
+	startedAt := make(map[string]time.Time, len(snapshot.components))
+
+	completeComponent := func(componentName string, err error) {
+		if err != nil {
+			componentErrors[componentName] = err
+		}
+
+		var duration time.Duration
+		if start, ok := startedAt[componentName]; ok {
+			duration = gs.options.Clock.Now().Sub(start)
+		}
+
+		gs.progressTracker.complete(componentName, err, duration)
+
+		gs.notify(func(listener Listener) {
+			listener.ComponentShutdownCompleted(componentName, err, duration)
+		})
+	}
+
+	results := make(chan componentResult, len(snapshot.components))
+	stop := make(chan struct{})
+
+	// The timeout of each phase and of each group starts when its first component is signaled
+	phaseContexts := make(map[int]context.Context, len(gs.options.PhaseTimeouts))
+	groupContexts := make(map[string]context.Context, len(snapshot.groups))
+
+	for {
+		// Signaling the components which are free to start shutting down
+		for _, c := range ordered {
+			componentName := c.name
+			if _, ok := waitingComponents[componentName]; !ok {
+				continue
+			}
+
+			if gs.options.MaxConcurrentShutdowns > 0 && len(remainingComponents) >= gs.options.MaxConcurrentShutdowns {
+				break
+			}
+
+			if !canStart(snapshot.components, c, waitingComponents, remainingComponents, gs.options.ReverseRegistrationOrder) {
+				continue
+			}
+
+			componentCtx := ctx
+			if budget, ok := gs.options.PhaseTimeouts[c.phase]; ok && budget > 0 {
+				phaseCtx, ok := phaseContexts[c.phase]
+				if !ok {
+					var cancel context.CancelFunc
+					phaseCtx, cancel = withTimeout(ctx, gs.options.Clock, budget)
+					defer cancel()
+
+					phaseContexts[c.phase] = phaseCtx
+				}
+
+				componentCtx = phaseCtx
+			}
+
+			if group, ok := snapshot.groups[c.group]; ok && group.Timeout > 0 {
+				groupCtx, ok := groupContexts[c.group]
+				if !ok {
+					var cancel context.CancelFunc
+					groupCtx, cancel = withTimeout(componentCtx, gs.options.Clock, group.Timeout)
+					defer cancel()
+
+					groupContexts[c.group] = groupCtx
+				}
+
+				componentCtx = groupCtx
+			}
+
+			c.reportNotReady()
+
+			if c.start != nil {
+				c.start <- componentCtx
+			}
+
+			delete(waitingComponents, componentName)
+			remainingComponents[componentName] = c
+			startedAt[componentName] = gs.options.Clock.Now()
+
+			go forwardResult(c, componentCtx, results, stop)
+		}
+
+		// All components were shutdown
+		if len(waitingComponents) == 0 && len(remainingComponents) == 0 {
+			return gs.finishDrain(snapshot, componentErrors, stop)
+		}
+
+		select {
+		case <-ctx.Done():
+			pending := make([]string, 0, len(remainingComponents)+len(waitingComponents))
+			for componentName := range remainingComponents {
+				pending = append(pending, componentName)
+			}
+			for componentName := range waitingComponents {
+				pending = append(pending, componentName)
+			}
+			sort.Strings(pending)
+			gs.runTimeoutHooks(pending)
+
+			for componentName := range remainingComponents {
+				completeComponent(componentName, ErrShutdownTimeout)
+			}
+
+			for componentName, c := range waitingComponents {
+				completeComponent(componentName, ErrShutdownTimeout)
+
+				// The component will never be signaled
+				if c.abandoned != nil {
+					close(c.abandoned)
+				}
+			}
+
+			return gs.finishDrain(snapshot, componentErrors, stop)
+
+		case result := <-results:
+			completeComponent(result.name, result.err)
+			delete(remainingComponents, result.name)
+		}
+	}
+}
+
+// forwardResult waits for the component to report and forwards its result. If the component's context is done first, the
+// component is reported as timed out.
+func forwardResult(c *component, ctx context.Context, results chan<- componentResult, stop <-chan struct{}) {
+	select {
+	case err := <-c.shutdownChan:
+		results <- componentResult{name: c.name, err: err}
+	case <-ctx.Done():
+		results <- componentResult{name: c.name, err: ErrShutdownTimeout}
+	case <-stop:
+	}
+}
+
+// finishDrain stops forwarding the results of the components and looks for leaked goroutines, before reporting the outcome of
+// the drain, so the leaks are part of the [ShutdownError.Report]
+func (gs *GracefulShutdown) finishDrain(snapshot drainSnapshot, componentErrors map[string]error, stop chan struct{}) error {
+	close(stop)
+	gs.progressTracker.finish()
+
+	gs.detectGoroutineLeaks()
+
+	if len(componentErrors) == 0 {
+		return nil
+	}
+
+	return gs.newShutdownError(snapshot, componentErrors)
+}
+
+// newShutdownError creates a [ShutdownError] from the component errors, summarizing the errors of each group
+func (gs *GracefulShutdown) newShutdownError(snapshot drainSnapshot, componentErrors map[string]error) ShutdownError {
+	groups := make(map[string]GroupSummary)
+
+	for componentName, c := range snapshot.components {
+		if c.group == "" {
+			continue
+		}
+
+		summary, ok := groups[c.group]
+		if !ok {
+			summary = GroupSummary{
+				ComponentErrors: make(map[string]error),
+			}
+		}
+
+		summary.Components = append(summary.Components, componentName)
+		if err, ok := componentErrors[componentName]; ok {
+			summary.ComponentErrors[componentName] = err
+		}
+
+		groups[c.group] = summary
+	}
+
+	shutdownErr := ShutdownError{
+		ComponentErrors: componentErrors,
+		Groups:          groups,
+		Report:          gs.progressTracker.report(),
+	}
+
+	if gs.options.DumpGoroutinesOnTimeout && shutdownErr.hasTimeout() {
+		shutdownErr.GoroutineDump = goroutineDump()
+
+		if gs.options.OnGoroutineDump != nil {
+			gs.options.OnGoroutineDump(shutdownErr.GoroutineDump)
+		}
+	}
+
+	return shutdownErr
+}
+
+// canStart returns true when none of the component's predecessors are still waiting or shutting down
+func canStart(components map[string]*component, c *component, waitingComponents map[string]*component, remainingComponents map[string]*component, reverse bool) bool {
+	for _, predecessor := range predecessors(components, c, reverse) {
+		if _, ok := waitingComponents[predecessor.name]; ok {
+			return false
+		}
+
+		if _, ok := remainingComponents[predecessor.name]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// predecessors returns the components which must be done shutting down before the given component may start.
+// This includes its declared dependencies, as well as all the components of the previous phases. When the components are shutdown
+// in reverse order, this also includes the components of the same phase which were registered after it.
+func predecessors(components map[string]*component, c *component, reverse bool) []*component {
+	predecessors := make([]*component, 0, len(c.dependsOn))
+
+	for _, name := range c.dependsOn {
+		if dependency, ok := components[name]; ok {
+			predecessors = append(predecessors, dependency)
+		}
+	}
+
+	for _, other := range components {
+		if other.phase > c.phase || (reverse && other.phase == c.phase && other.order > c.order) {
+			predecessors = append(predecessors, other)
+		}
+	}
+
+	return predecessors
+}
+
+// dependsOn returns true if the component transitively depends on the target component
+func dependsOn(components map[string]*component, c *component, target string, visited map[string]bool, reverse bool) bool {
+	for _, predecessor := range predecessors(components, c, reverse) {
+		if predecessor.name == target {
+			return true
+		}
+
+		if visited[predecessor.name] {
+			continue
+		}
+		visited[predecessor.name] = true
+
+		if dependsOn(components, predecessor, target, visited, reverse) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// SignalNotifier relays OS Signals to a channel. Its methods match the ones of the os/signal package.
+type SignalNotifier interface {
+	// Notify relays the given signals to the channel
+	Notify(c chan<- os.Signal, sig ...os.Signal)
+	// Stop stops relaying any signal to the channel
+	Stop(c chan<- os.Signal)
+}
+
+// OSSignalNotifier is the [SignalNotifier] relaying the signals received by the process
+var OSSignalNotifier SignalNotifier = osSignalNotifier{}
+
+type osSignalNotifier struct{}
+
+func (osSignalNotifier) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signal.Notify(c, sig...)
+}
+
+func (osSignalNotifier) Stop(c chan<- os.Signal) {
+	signal.Stop(c)
+}
+
+// SignalHandler is invoked when the OS Signal it was registered for is received. The context is the AppContext.
+type SignalHandler func(ctx context.Context) error
+
+// HandleSignal registers a handler for the given OS Signal. While WaitForShutdown is running, receiving the signal invokes the
+// handler instead of triggering the shutdown, even if the signal is part of [GracefulShutdownOptions.Signals]. Handlers run in
+// their own goroutine, so a slow handler does not prevent the shutdown from being triggered. Errors returned by the handler are
+// reported to [GracefulShutdownOptions.OnSignalError].
+//
+// Registering a handler for a signal which already has one replaces it.
+func (gs *GracefulShutdown) HandleSignal(sig os.Signal, handler SignalHandler) {
+	gs.signalMutex.Lock()
+	defer gs.signalMutex.Unlock()
+
+	gs.signalHandlers[sig] = handler
+
+	if gs.signals != nil {
+		gs.options.SignalNotifier.Notify(gs.signals, sig)
+	}
+}
+
+// TriggerShutdownSignal simulates the reception of an OS Signal, without the process actually receiving it. This is useful for
+// integration tests and admin endpoints, especially where sending signals is awkward, such as on Windows.
+//
+// A signal registered with [GracefulShutdown.HandleSignal] invokes its handler. Any other signal triggers the shutdown with a
+// [SignalError] as its reason, whether it is part of [GracefulShutdownOptions.Signals] or not. When WaitForShutdown is running,
+// it performs the shutdown and reports its outcome. Otherwise, the shutdown is performed in the background. Triggering a signal
+// once the shutdown was requested has no effect, even when [GracefulShutdownOptions.ForceExitOnSecondSignal] is enabled.
+func (gs *GracefulShutdown) TriggerShutdownSignal(sig os.Signal) {
+	if gs.dispatchSignal(sig) {
+		return
+	}
+
+	gs.requestShutdown(SignalError{Signal: sig})
+}
+
+// subscribeSignals starts relaying both the shutdown signals and the handled signals to the channel
+func (gs *GracefulShutdown) subscribeSignals(signals chan os.Signal) {
+	gs.signalMutex.Lock()
+	defer gs.signalMutex.Unlock()
+
+	gs.signals = signals
+
+	gs.options.SignalNotifier.Notify(signals, gs.options.Signals...)
+	for sig := range gs.signalHandlers {
+		gs.options.SignalNotifier.Notify(signals, sig)
+	}
+}
+
+func (gs *GracefulShutdown) unsubscribeSignals() {
+	gs.signalMutex.Lock()
+	defer gs.signalMutex.Unlock()
+
+	gs.options.SignalNotifier.Stop(gs.signals)
+	gs.signals = nil
+}
+
+// dispatchSignal invokes the handler of the signal, if any. It returns true if the signal was handled.
+func (gs *GracefulShutdown) dispatchSignal(sig os.Signal) bool {
+	gs.signalMutex.RLock()
+	handler, ok := gs.signalHandlers[sig]
+	gs.signalMutex.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	gs.recordEvent(Event{Type: EventSignalHandled, Signal: sig})
+
+	ctx := gs.AppContext()
+	go func() {
+		err := handler(ctx)
+		if err != nil && gs.options.OnSignalError != nil {
+			gs.options.OnSignalError(sig, err)
+		}
+	}()
+
+	return true
+}
+
+// nextShutdownReason blocks until a signal which is not handled is received, until the shutdown is requested internally, or
+// until a shutdown started elsewhere is draining the components
+func (gs *GracefulShutdown) nextShutdownReason(signals <-chan os.Signal) error {
+	draining := gs.Draining()
+
+	for {
+		select {
+		case sig := <-signals:
+			if !gs.dispatchSignal(sig) {
+				return SignalError{Signal: sig}
+			}
+		case reason := <-gs.shutdownRequests:
+			return reason
+		case <-draining:
+			return gs.ShutdownReason()
+		}
+	}
+}
+
+// requestShutdown triggers the shutdown with the given reason. If WaitForShutdown is running, it performs the shutdown and
+// reports its outcome. Otherwise, the shutdown is performed in the background. The request is queued either way, so a
+// WaitForShutdown invoked afterwards reports the outcome of that shutdown instead of waiting for a signal.
+func (gs *GracefulShutdown) requestShutdown(reason error) {
+	gs.signalMutex.RLock()
+	isWaiting := gs.signals != nil
+	gs.signalMutex.RUnlock()
+
+	select {
+	case gs.shutdownRequests <- reason:
+	default:
+		// A shutdown was already requested
+	}
+
+	if !isWaiting {
+		go func() {
+			_ = gs.shutdown(context.Background(), reason)
+		}()
+	}
+}
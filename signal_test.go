@@ -0,0 +1,85 @@
+package lifecycle_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenShutdownSignalIsTriggered_ShouldShutdownWaitForShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	shutdownChan, err := gs.RegisterComponent("component")
+	assert.NoError(err)
+
+	go func() {
+		<-gs.AppContext().Done()
+		shutdownChan <- nil
+	}()
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	gs.TriggerShutdownSignal(syscall.SIGTERM)
+
+	select {
+	case err := <-done:
+		assert.NoError(err)
+	case <-time.After(time.Second):
+		assert.Fail("WaitForShutdown should have returned")
+	}
+
+	assert.Equal(lifecycle.SignalError{Signal: syscall.SIGTERM}, gs.ShutdownReason())
+}
+
+func Test_WhenHandledSignalIsTriggered_ShouldInvokeHandler(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	handled := make(chan os.Signal, 1)
+	gs.HandleSignal(syscall.SIGHUP, func(ctx context.Context) error {
+		handled <- syscall.SIGHUP
+		return nil
+	})
+
+	gs.TriggerShutdownSignal(syscall.SIGHUP)
+
+	select {
+	case sig := <-handled:
+		assert.Equal(syscall.SIGHUP, sig)
+	case <-time.After(time.Second):
+		assert.Fail("handler should have been invoked")
+	}
+
+	assert.NoError(gs.AppContext().Err(), "handled signal should not trigger the shutdown")
+}
+
+func Test_WhenShutdownSignalIsTriggeredWithoutWaiting_ShouldShutdownInBackground(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	finished := make(chan error, 1)
+	gs.OnShutdownComplete(func(err error) {
+		finished <- err
+	})
+
+	gs.TriggerShutdownSignal(os.Interrupt)
+
+	select {
+	case err := <-finished:
+		assert.NoError(err)
+	case <-time.After(time.Second):
+		assert.Fail("shutdown should have completed")
+	}
+
+	assert.Equal(lifecycle.SignalError{Signal: os.Interrupt}, gs.ShutdownReason())
+}
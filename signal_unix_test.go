@@ -0,0 +1,91 @@
+//go:build unix
+
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenHandledSignalIsReceived_ShouldInvokeHandlerWithoutShutdown(t *testing.T) {
+	assert := assert2.New(t)
+
+	expectedErr := errors.New("reload failed")
+	signalErrors := make(chan error, 1)
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Signals: []os.Signal{syscall.SIGUSR1, syscall.SIGHUP},
+		OnSignalError: func(sig os.Signal, err error) {
+			signalErrors <- err
+		},
+	})
+
+	calls := atomic.Int32{}
+	gs.HandleSignal(syscall.SIGHUP, func(ctx context.Context) error {
+		calls.Add(1)
+		return expectedErr
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case err := <-signalErrors:
+		assert.ErrorIs(err, expectedErr)
+	case <-time.After(time.Second):
+		assert.Fail("handler error should have been reported")
+	}
+
+	assert.Equal(int32(1), calls.Load())
+	assert.NoError(gs.AppContext().Err(), "handled signal should not trigger the shutdown")
+
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	assert.NoError(<-done)
+	assert.Error(gs.AppContext().Err())
+}
+
+func Test_WhenReloadManagerIsAttached_ShouldReloadOnSignal(t *testing.T) {
+	assert := assert2.New(t)
+
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Signals: []os.Signal{syscall.SIGUSR1},
+	})
+
+	rm := lifecycle.NewReloadManager()
+	reloaded := make(chan struct{}, 1)
+	_ = rm.Register("config", func(ctx context.Context) error {
+		reloaded <- struct{}{}
+		return nil
+	})
+	rm.Attach(gs)
+
+	done := make(chan error)
+	go func() {
+		done <- gs.WaitForShutdown()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		assert.Fail("components should have been reloaded")
+	}
+
+	assert.NoError(syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	assert.NoError(<-done)
+}
@@ -0,0 +1,42 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// sqlDBPollInterval is the interval at which the connections in use are checked while draining a [*sql.DB]
+const sqlDBPollInterval = 10 * time.Millisecond
+
+// RegisterSQLDB registers a [*sql.DB] as a component. When signaled, the idle connections are released, then the component waits
+// for the connections in use to be returned to the pool, bounded by the remaining shutdown time. The pool is closed afterwards,
+// even if connections are still in use.
+func (gs *GracefulShutdown) RegisterSQLDB(name string, db *sql.DB, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, func(ctx context.Context) error {
+		db.SetMaxIdleConns(0)
+
+		err := waitForSQLConns(ctx, db)
+		closeErr := db.Close()
+		if err != nil {
+			return err
+		}
+
+		return closeErr
+	}, opts)
+}
+
+func waitForSQLConns(ctx context.Context, db *sql.DB) error {
+	ticker := time.NewTicker(sqlDBPollInterval)
+	defer ticker.Stop()
+
+	for db.Stats().InUse > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
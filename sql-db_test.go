@@ -0,0 +1,87 @@
+package lifecycle_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(name string) (driver.Conn, error) {
+	return fakeSQLConn{}, nil
+}
+
+type fakeSQLConn struct{}
+
+func (fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeSQLConn) Close() error {
+	return nil
+}
+
+func (fakeSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func init() {
+	sql.Register("lifecycle-fake", fakeSQLDriver{})
+}
+
+func Test_WhenSQLDBIsRegistered_ShouldWaitForConnectionsInUse(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	db, err := sql.Open("lifecycle-fake", "")
+	assert.NoError(err)
+	assert.NoError(gs.RegisterSQLDB("db", db))
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(err)
+
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = conn.Close()
+		close(released)
+	}()
+
+	assert.NoError(gs.Shutdown())
+
+	select {
+	case <-released:
+	default:
+		assert.Fail("shutdown should wait for the connection to be released")
+	}
+
+	assert.Equal(0, db.Stats().OpenConnections)
+	assert.Error(db.Ping(), "db should be closed")
+}
+
+func Test_WhenSQLDBConnectionIsNotReleased_ShouldTimeoutAndClose(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdownWithOptions(context.Background(), lifecycle.GracefulShutdownOptions{
+		Timeout: 100 * time.Millisecond,
+	})
+
+	db, err := sql.Open("lifecycle-fake", "")
+	assert.NoError(err)
+	assert.NoError(gs.RegisterSQLDB("db", db))
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(err)
+	defer conn.Close()
+
+	assert.Error(gs.Shutdown())
+	assert.Eventually(func() bool {
+		return db.Ping() != nil
+	}, time.Second, 10*time.Millisecond, "db should be closed")
+}
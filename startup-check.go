@@ -0,0 +1,45 @@
+package lifecycle
+
+import "sync/atomic"
+
+// StartupComponentCheck is a component check which latches: it reports the component as not ready until its check passes
+// once, then as ready forever, even if the check later fails. This matches the semantics of Kubernetes startup probes, and
+// prevents transient failures of a dependency from restarting an application which started successfully.
+type StartupComponentCheck struct {
+	name    string
+	started *atomic.Bool
+
+	checkFn func() bool
+}
+
+// Name is the name of the component being checked for
+func (component *StartupComponentCheck) Name() string {
+	return component.name
+}
+
+// Ready returns true if the check passed at least once. Until it does, the check is performed on every call.
+func (component *StartupComponentCheck) Ready() bool {
+	if component.started.Load() {
+		return true
+	}
+
+	if component.checkFn() {
+		component.started.Store(true)
+		return true
+	}
+
+	return false
+}
+
+// RegisterStartupComponent creates a new [StartupComponentCheck] with the given [checkFn] and registers it
+func (rdy *ReadyCheck) RegisterStartupComponent(name string, checkFn func() bool, opts ...CheckOption) *StartupComponentCheck {
+	startupComponent := &StartupComponentCheck{
+		name:    name,
+		started: &atomic.Bool{},
+		checkFn: checkFn,
+	}
+
+	rdy.RegisterComponent(name, startupComponent, opts...)
+
+	return startupComponent
+}
@@ -0,0 +1,31 @@
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenStartupCheckPassesOnce_ShouldStayReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+
+	checks := 0
+	isUp := false
+	startupCheck := readycheck.RegisterStartupComponent("migrations", func() bool {
+		checks++
+		return isUp
+	})
+
+	assert.False(readycheck.Ready(), "check has not passed yet")
+	assert.False(startupCheck.Ready())
+
+	isUp = true
+	assert.True(readycheck.Ready())
+
+	isUp = false
+	assert.True(readycheck.Ready(), "check should stay ready once it passed")
+	assert.Equal(3, checks, "check should no longer be performed once it passed")
+}
@@ -0,0 +1,319 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BootstrapOptions are options used in conjunction with the [Bootstrapper] type
+type BootstrapOptions struct {
+	// StepTimeout is the time allocated to each step, unless the step specifies its own timeout with [StepTimeout]
+	//
+	// Default: 30s
+	StepTimeout time.Duration
+	// RollbackTimeout is the time allocated to roll back all the started steps when the startup fails
+	//
+	// Default: 5s
+	RollbackTimeout time.Duration
+}
+
+// Bootstrapper is an utility that allows you to run the initialization steps of your application in order, and to roll back
+// the steps which were already started when one of them fails.
+type Bootstrapper struct {
+	stepMutex *sync.Mutex
+
+	options BootstrapOptions
+	steps   []*step
+	started bool
+}
+
+// StepFn performs an initialization step. The context is done when the step's timeout expires.
+type StepFn func(ctx context.Context) error
+
+type step struct {
+	name      string
+	fn        StepFn
+	rollback  StepFn
+	timeout   time.Duration
+	dependsOn []string
+}
+
+// StepOption configures how a step is performed
+type StepOption func(s *step)
+
+// StepTimeout overrides the time allocated to the step
+func StepTimeout(timeout time.Duration) StepOption {
+	return func(s *step) {
+		s.timeout = timeout
+	}
+}
+
+// StepDependsOn declares that the step may only be performed once the given steps completed. When no step declares dependencies,
+// steps are performed in the order they were added.
+func StepDependsOn(names ...string) StepOption {
+	return func(s *step) {
+		s.dependsOn = append(s.dependsOn, names...)
+	}
+}
+
+// WithRollback sets the function undoing the step. It is invoked when a later step fails.
+func WithRollback(rollback StepFn) StepOption {
+	return func(s *step) {
+		s.rollback = rollback
+	}
+}
+
+var (
+	DefaultStepTimeout     = 30 * time.Second
+	DefaultRollbackTimeout = 5 * time.Second
+
+	ErrStepAlreadyAdded   = errors.New("step was already added")
+	ErrUnknownStep        = errors.New("step depends on an unknown step")
+	ErrStepTimeout        = errors.New("step took too long to complete")
+	ErrAlreadyStarted     = errors.New("startup has already occurred")
+	ErrStepNotPerformed   = errors.New("step was not performed")
+	ErrRollbackIncomplete = errors.New("rollback took too long to complete")
+)
+
+// NewBootstrapperWithOptions creates a new instance of [*Bootstrapper] with the given options
+func NewBootstrapperWithOptions(options BootstrapOptions) *Bootstrapper {
+	if options.StepTimeout == 0 {
+		options.StepTimeout = DefaultStepTimeout
+	}
+
+	if options.RollbackTimeout == 0 {
+		options.RollbackTimeout = DefaultRollbackTimeout
+	}
+
+	return &Bootstrapper{
+		stepMutex: &sync.Mutex{},
+		options:   options,
+		steps:     make([]*step, 0),
+	}
+}
+
+// NewBootstrapper creates a new instance of [*Bootstrapper]. Default options will be used.
+func NewBootstrapper() *Bootstrapper {
+	return NewBootstrapperWithOptions(BootstrapOptions{})
+}
+
+// AddStep adds an initialization step. Adding the same step twice returns a [ErrStepAlreadyAdded] error, and adding a step once
+// the startup occurred returns a [ErrAlreadyStarted] error.
+func (b *Bootstrapper) AddStep(name string, fn StepFn, opts ...StepOption) error {
+	s := &step{
+		name:    name,
+		fn:      fn,
+		timeout: b.options.StepTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	b.stepMutex.Lock()
+	defer b.stepMutex.Unlock()
+
+	if b.started {
+		return ErrAlreadyStarted
+	}
+
+	for _, other := range b.steps {
+		if other.name == name {
+			return ErrStepAlreadyAdded
+		}
+	}
+
+	b.steps = append(b.steps, s)
+
+	return nil
+}
+
+// removeStep removes a step which was added, unless the startup already occurred
+func (b *Bootstrapper) removeStep(name string) {
+	b.stepMutex.Lock()
+	defer b.stepMutex.Unlock()
+
+	if b.started {
+		return
+	}
+
+	for i, s := range b.steps {
+		if s.name == name {
+			b.steps = append(b.steps[:i], b.steps[i+1:]...)
+			return
+		}
+	}
+}
+
+// Steps returns the names of the steps, in the order they will be performed
+func (b *Bootstrapper) Steps() ([]string, error) {
+	b.stepMutex.Lock()
+	defer b.stepMutex.Unlock()
+
+	ordered, err := b.orderedSteps()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(ordered))
+	for i, s := range ordered {
+		names[i] = s.name
+	}
+
+	return names, nil
+}
+
+// Start performs the steps one after the other, each within its own timeout. If a step fails, the steps which were already
+// performed are rolled back in reverse order, and a [StartupError] is returned.
+//
+// Invoking Start multiple times returns a [ErrAlreadyStarted] error.
+func (b *Bootstrapper) Start(ctx context.Context) error {
+	b.stepMutex.Lock()
+	if b.started {
+		b.stepMutex.Unlock()
+		return ErrAlreadyStarted
+	}
+	b.started = true
+
+	steps, err := b.orderedSteps()
+	b.stepMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for i, s := range steps {
+		err := performStep(ctx, s.fn, s.timeout, ErrStepTimeout)
+		if err == nil {
+			continue
+		}
+
+		startupErr := StartupError{
+			FailedStep:     s.name,
+			StepErrors:     map[string]error{s.name: err},
+			RollbackErrors: b.rollback(steps[:i]),
+		}
+
+		for _, skipped := range steps[i+1:] {
+			startupErr.StepErrors[skipped.name] = ErrStepNotPerformed
+		}
+
+		return startupErr
+	}
+
+	return nil
+}
+
+// rollback undoes the given steps in reverse order
+func (b *Bootstrapper) rollback(steps []*step) map[string]error {
+	rollbackErrors := make(map[string]error)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.options.RollbackTimeout)
+	defer cancel()
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if s.rollback == nil {
+			continue
+		}
+
+		err := performStep(ctx, s.rollback, b.options.RollbackTimeout, ErrRollbackIncomplete)
+		if err != nil {
+			rollbackErrors[s.name] = err
+		}
+	}
+
+	return rollbackErrors
+}
+
+// performStep invokes the step's function, and gives up on it once the timeout expires
+func performStep(ctx context.Context, fn StepFn, timeout time.Duration, timeoutErr error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- callShutdownFn(ctx, fn)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return timeoutErr
+	}
+}
+
+// orderedSteps sorts the steps according to their dependencies, keeping the order in which they were added otherwise
+func (b *Bootstrapper) orderedSteps() ([]*step, error) {
+	byName := make(map[string]*step, len(b.steps))
+	for _, s := range b.steps {
+		byName[s.name] = s
+	}
+
+	for _, s := range b.steps {
+		for _, dependency := range s.dependsOn {
+			if _, ok := byName[dependency]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownStep, s.name, dependency)
+			}
+		}
+	}
+
+	ordered := make([]*step, 0, len(b.steps))
+	done := make(map[string]bool, len(b.steps))
+
+	for len(ordered) < len(b.steps) {
+		progressed := false
+
+		for _, s := range b.steps {
+			if done[s.name] || !stepDependenciesDone(s, done) {
+				continue
+			}
+
+			ordered = append(ordered, s)
+			done[s.name] = true
+			progressed = true
+
+			// Restarting from the first step preserves the order in which the steps were added
+			break
+		}
+
+		if !progressed {
+			return nil, ErrDependencyCycle
+		}
+	}
+
+	return ordered, nil
+}
+
+func stepDependenciesDone(s *step, done map[string]bool) bool {
+	for _, dependency := range s.dependsOn {
+		if !done[dependency] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// StartupError details errors by step
+type StartupError struct {
+	// FailedStep is the step which caused the startup to fail
+	FailedStep string
+	// StepErrors are the errors of the failed step, as well as the steps which were not performed
+	StepErrors map[string]error
+	// RollbackErrors are the errors reported while rolling back the performed steps
+	RollbackErrors map[string]error
+}
+
+func (err StartupError) Error() string {
+	return fmt.Sprintf("error while starting up, step %s failed (%+v), rollback errors (%+v)", err.FailedStep, err.StepErrors, err.RollbackErrors)
+}
+
+// Unwrap returns the error of the failed step
+func (err StartupError) Unwrap() error {
+	return err.StepErrors[err.FailedStep]
+}
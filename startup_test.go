@@ -0,0 +1,85 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenStartingUp_ShouldPerformStepsInOrder(t *testing.T) {
+	assert := assert2.New(t)
+	b := lifecycle.NewBootstrapper()
+
+	order := make([]string, 0)
+	record := func(name string) lifecycle.StepFn {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	assert.NoError(b.AddStep("http-server", record("http-server"), lifecycle.StepDependsOn("db", "cache")))
+	assert.NoError(b.AddStep("config", record("config")))
+	assert.NoError(b.AddStep("db", record("db"), lifecycle.StepDependsOn("config")))
+	assert.NoError(b.AddStep("cache", record("cache")))
+	assert.ErrorIs(b.AddStep("cache", record("cache")), lifecycle.ErrStepAlreadyAdded)
+
+	assert.NoError(b.Start(context.Background()))
+	assert.Equal([]string{"config", "db", "cache", "http-server"}, order)
+
+	assert.ErrorIs(b.Start(context.Background()), lifecycle.ErrAlreadyStarted)
+}
+
+func Test_WhenStepFails_ShouldRollbackPerformedSteps(t *testing.T) {
+	assert := assert2.New(t)
+	b := lifecycle.NewBootstrapperWithOptions(lifecycle.BootstrapOptions{
+		StepTimeout: time.Second,
+	})
+
+	rolledBack := make([]string, 0)
+	rollback := func(name string) lifecycle.StepOption {
+		return lifecycle.WithRollback(func(ctx context.Context) error {
+			rolledBack = append(rolledBack, name)
+			return nil
+		})
+	}
+	noop := func(ctx context.Context) error {
+		return nil
+	}
+
+	_ = b.AddStep("config", noop, rollback("config"))
+	_ = b.AddStep("db", noop, rollback("db"))
+	_ = b.AddStep("cache", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, lifecycle.StepTimeout(50*time.Millisecond), rollback("cache"))
+	_ = b.AddStep("http-server", noop, rollback("http-server"))
+
+	err := b.Start(context.Background())
+
+	startupErr := lifecycle.StartupError{}
+	if !assert.ErrorAs(err, &startupErr) {
+		return
+	}
+
+	assert.Equal("cache", startupErr.FailedStep)
+	assert.ErrorIs(err, lifecycle.ErrStepTimeout)
+	assert.ErrorIs(startupErr.StepErrors["http-server"], lifecycle.ErrStepNotPerformed)
+	assert.Empty(startupErr.RollbackErrors)
+	assert.Equal([]string{"db", "config"}, rolledBack)
+}
+
+func Test_WhenStepsDependOnUnknownStep_ShouldFail(t *testing.T) {
+	assert := assert2.New(t)
+	b := lifecycle.NewBootstrapper()
+
+	_ = b.AddStep("a", func(ctx context.Context) error {
+		return errors.New("should not be performed")
+	}, lifecycle.StepDependsOn("unknown"))
+
+	assert.ErrorIs(b.Start(context.Background()), lifecycle.ErrUnknownStep)
+}
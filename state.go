@@ -0,0 +1,125 @@
+package lifecycle
+
+import "sync"
+
+// State is the state of an application's lifecycle. States only ever move forward, in the order they are declared.
+type State int
+
+const (
+	// StateStarting means the application is initializing its components
+	StateStarting State = iota
+	// StateRunning means the application is up and running
+	StateRunning
+	// StateDraining means the shutdown was requested and the components are shutting down
+	StateDraining
+	// StateStopped means all components shut down successfully. It is a terminal state.
+	StateStopped
+	// StateFailed means the application failed to start, or failed to shut down gracefully. It is a terminal state.
+	StateFailed
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateDraining:
+		return "draining"
+	case StateStopped:
+		return "stopped"
+	case StateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Terminal reports whether no further transition can happen from this state
+func (s State) Terminal() bool {
+	return s == StateStopped || s == StateFailed
+}
+
+// subscriberBufferSize fits every state, so that publishing never blocks since states only move forward
+const subscriberBufferSize = int(StateFailed) + 1
+
+type stateMachine struct {
+	mutex       *sync.Mutex
+	state       State
+	subscribers []chan State
+}
+
+func newStateMachine(initial State) *stateMachine {
+	return &stateMachine{
+		mutex: &sync.Mutex{},
+		state: initial,
+	}
+}
+
+func (sm *stateMachine) current() State {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	return sm.state
+}
+
+// transition moves to the given state, unless it would move the state backward
+func (sm *stateMachine) transition(state State) bool {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	if state <= sm.state || sm.state.Terminal() {
+		return false
+	}
+
+	sm.state = state
+	for _, subscriber := range sm.subscribers {
+		subscriber <- state
+		if state.Terminal() {
+			close(subscriber)
+		}
+	}
+
+	if state.Terminal() {
+		sm.subscribers = nil
+	}
+
+	return true
+}
+
+// reset moves back to the given state. Subscribers were already released when the terminal state was reached.
+func (sm *stateMachine) reset(state State) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.state = state
+}
+
+func (sm *stateMachine) subscribe() <-chan State {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	subscriber := make(chan State, subscriberBufferSize)
+	subscriber <- sm.state
+
+	if sm.state.Terminal() {
+		close(subscriber)
+	} else {
+		sm.subscribers = append(sm.subscribers, subscriber)
+	}
+
+	return subscriber
+}
+
+// State returns the current state of the application. A [GracefulShutdown] starts in the [StateRunning] state, unless it
+// belongs to an [App].
+func (gs *GracefulShutdown) State() State {
+	return gs.state.current()
+}
+
+// SubscribeState returns a channel which receives the current state, then every subsequent transition. The channel is closed
+// once a terminal state is reached. Transitions are buffered, so slow subscribers never block the lifecycle.
+func (gs *GracefulShutdown) SubscribeState() <-chan State {
+	return gs.state.subscribe()
+}
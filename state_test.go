@@ -0,0 +1,88 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func collectStates(ch <-chan lifecycle.State) []lifecycle.State {
+	states := []lifecycle.State{}
+	for state := range ch {
+		states = append(states, state)
+	}
+
+	return states
+}
+
+func Test_WhenAppRunsToCompletion_ShouldPublishEveryState(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	states := app.SubscribeState()
+	assert.Equal(lifecycle.StateStarting, app.State())
+
+	assert.NoError(app.Start(context.Background()))
+	assert.Equal(lifecycle.StateRunning, app.State())
+
+	assert.NoError(app.Shutdown())
+	assert.Equal(lifecycle.StateStopped, app.State())
+
+	assert.Equal([]lifecycle.State{
+		lifecycle.StateStarting,
+		lifecycle.StateRunning,
+		lifecycle.StateDraining,
+		lifecycle.StateStopped,
+	}, collectStates(states))
+}
+
+func Test_WhenAppFailsToStart_ShouldBeFailed(t *testing.T) {
+	assert := assert2.New(t)
+	app := lifecycle.NewApp(context.Background())
+
+	_ = app.Register("db", lifecycle.AppComponent{
+		Start: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		},
+	})
+
+	assert.Error(app.Start(context.Background()))
+	assert.Equal(lifecycle.StateFailed, app.State())
+
+	assert.NoError(app.Shutdown())
+	assert.Equal(lifecycle.StateFailed, app.State(), "terminal state should not change")
+	assert.Equal([]lifecycle.State{lifecycle.StateFailed}, collectStates(app.SubscribeState()))
+}
+
+func Test_GracefulShutdown_WhenComponentFails_ShouldBeFailed(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.Equal(lifecycle.StateRunning, gs.State())
+	states := gs.SubscribeState()
+
+	_ = gs.RegisterComponentWithFn("db", func() error {
+		return errors.New("failed to close")
+	})
+
+	assert.Error(gs.Shutdown())
+	assert.Equal([]lifecycle.State{
+		lifecycle.StateRunning,
+		lifecycle.StateDraining,
+		lifecycle.StateFailed,
+	}, collectStates(states))
+}
+
+func Test_GracefulShutdown_WhenReset_ShouldBeRunningAgain(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal(lifecycle.StateStopped, gs.State())
+
+	assert.NoError(gs.Reset())
+	assert.Equal(lifecycle.StateRunning, gs.State())
+}
@@ -0,0 +1,143 @@
+package lifecycle
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Status is the health of a component, or of the whole application. Statuses are ordered from the best to the worst.
+type Status int
+
+const (
+	// StatusReady means the component is fully functional
+	StatusReady Status = iota
+	// StatusDegraded means the component is functional, but suffers from non-critical issues, such as a cold cache. A degraded
+	// component is still considered ready.
+	StatusDegraded
+	// StatusNotReady means the component is not functional
+	StatusNotReady
+)
+
+// String returns the name of the status
+func (s Status) String() string {
+	switch s {
+	case StatusReady:
+		return "ready"
+	case StatusDegraded:
+		return "degraded"
+	case StatusNotReady:
+		return "not_ready"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the status as its name
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a status from its name
+func (s *Status) UnmarshalText(text []byte) error {
+	for _, status := range []Status{StatusReady, StatusDegraded, StatusNotReady} {
+		if status.String() == string(text) {
+			*s = status
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown status %q", text)
+}
+
+// StatusCheck is a [ComponentCheck] able to report itself as degraded. Its Ready method must return true when it is degraded.
+type StatusCheck interface {
+	ComponentCheck
+	// Status returns the health of the component
+	Status() Status
+}
+
+func componentStatus(component ComponentCheck) Status {
+	if statusCheck, ok := component.(StatusCheck); ok {
+		return statusCheck.Status()
+	}
+
+	if component.Ready() {
+		return StatusReady
+	}
+
+	return StatusNotReady
+}
+
+func (rdy *ReadyCheck) checkStatuses(components []ComponentCheck) []Status {
+	return checkComponents(components, rdy.options.CheckTimeout, componentStatus, func(ComponentCheck) Status {
+		return StatusNotReady
+	})
+}
+
+// Status returns the worst status among the components. Non-critical components which are not ready are considered
+// [StatusDegraded]. While draining, the status is [StatusNotReady].
+func (rdy *ReadyCheck) Status() Status {
+	return rdy.status(rdy.snapshotComponents())
+}
+
+func (rdy *ReadyCheck) status(components []ComponentCheck) Status {
+	if rdy.draining.Load() {
+		return StatusNotReady
+	}
+
+	return rdy.worstStatus(components, rdy.checkStatuses(components))
+}
+
+// worstStatus returns the worst status given the status of each component
+func (rdy *ReadyCheck) worstStatus(components []ComponentCheck, statuses []Status) Status {
+	if rdy.draining.Load() {
+		return StatusNotReady
+	}
+
+	status := StatusReady
+	for i, componentStatus := range statuses {
+		// A non-critical component which is not ready only degrades the application
+		if componentStatus == StatusNotReady && !rdy.isCritical(components[i]) {
+			componentStatus = StatusDegraded
+		}
+
+		if componentStatus > status {
+			status = componentStatus
+		}
+	}
+
+	return status
+}
+
+// ExplainStatus returns a map detailling the status of each component
+func (rdy *ReadyCheck) ExplainStatus() map[string]Status {
+	return rdy.explainStatus(rdy.snapshotComponents())
+}
+
+func (rdy *ReadyCheck) explainStatus(components []ComponentCheck) map[string]Status {
+	statuses := rdy.checkStatuses(components)
+
+	explanation := make(map[string]Status, len(components))
+
+	for i, component := range components {
+		explanation[component.Name()] = statuses[i]
+	}
+
+	return explanation
+}
+
+// warnings lists the degraded components, and the non-critical components which are not ready
+func (rdy *ReadyCheck) warnings(statuses map[string]Status) []string {
+	rdy.componentsMutex.RLock()
+	defer rdy.componentsMutex.RUnlock()
+
+	warnings := make([]string, 0)
+	for name, status := range statuses {
+		if status == StatusDegraded || (status == StatusNotReady && rdy.settings[name].nonCritical) {
+			warnings = append(warnings, name)
+		}
+	}
+	sort.Strings(warnings)
+
+	return warnings
+}
@@ -0,0 +1,38 @@
+package lifecycle_test
+
+import (
+	"testing"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenComponentsHaveDifferentStatuses_ShouldAggregateToTheWorst(t *testing.T) {
+	assert := assert2.New(t)
+
+	readycheck := lifecycle.NewReadyCheck()
+	db := readycheck.RegisterPushComponent("db")
+	db.SetReady(true)
+	cache := readycheck.RegisterPushComponent("cache")
+	cache.SetStatus(lifecycle.StatusDegraded)
+	readycheck.RegisterComponent("custom", fakeComponentCheck{name: "custom", isReady: true})
+
+	assert.Equal(lifecycle.StatusDegraded, readycheck.Status())
+	assert.True(readycheck.Ready(), "degraded components should still be ready")
+	assert.Equal(map[string]lifecycle.Status{
+		"db":     lifecycle.StatusReady,
+		"cache":  lifecycle.StatusDegraded,
+		"custom": lifecycle.StatusReady,
+	}, readycheck.ExplainStatus())
+
+	db.SetStatus(lifecycle.StatusNotReady)
+	assert.Equal(lifecycle.StatusNotReady, readycheck.Status())
+	assert.False(readycheck.Ready())
+
+	db.SetReady(true)
+	cache.SetReady(true)
+	assert.Equal(lifecycle.StatusReady, readycheck.Status())
+
+	readycheck.SetDraining(true)
+	assert.Equal(lifecycle.StatusNotReady, readycheck.Status())
+}
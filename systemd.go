@@ -0,0 +1,77 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SystemdNotify sends a state notification to systemd, such as "READY=1" or "WATCHDOG=1". It does nothing when the process is
+// not supervised by systemd, that is when the NOTIFY_SOCKET environment variable is not set.
+func SystemdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// Abstract sockets are prefixed with '@' in the environment variable
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// systemdWatchdogInterval returns the interval at which the systemd watchdog must be fed, which is half its timeout. The
+// watchdog is disabled when WATCHDOG_USEC is not set, or when WATCHDOG_PID designates another process.
+func systemdWatchdogInterval() (time.Duration, bool) {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, false
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2, true
+}
+
+// RunSystemdWatchdog feeds the systemd watchdog as long as all the poll and pulse checks are ready, until the context is done.
+// Once a check fails, the watchdog is no longer fed, letting systemd restart the process. See [ReadyCheck.Live]. The watchdog
+// keeps being fed while the ReadyCheck is draining, so systemd does not kill the process in the middle of its drain. The
+// interval is measured with the [ReadyCheckOptions.Clock].
+//
+// When the systemd watchdog is not enabled, RunSystemdWatchdog returns immediately. It is meant to be registered as a runner
+// with [GracefulShutdown.RegisterRunner].
+func (rdy *ReadyCheck) RunSystemdWatchdog(ctx context.Context) error {
+	interval, enabled := systemdWatchdogInterval()
+	if !enabled {
+		return nil
+	}
+
+	for {
+		if rdy.Draining() || rdy.Live() {
+			err := SystemdNotify("WATCHDOG=1")
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-rdy.options.Clock.After(interval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,136 @@
+//go:build unix
+
+package lifecycle_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/lifecycletest"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func listenNotifySocket(t *testing.T) *net.UnixConn {
+	dir, err := os.MkdirTemp("", "sd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	t.Setenv("NOTIFY_SOCKET", socket)
+
+	return conn
+}
+
+func readNotification(conn *net.UnixConn, timeout time.Duration) (string, bool) {
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+
+	buffer := make([]byte, 64)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return "", false
+	}
+
+	return string(buffer[:n]), true
+}
+
+func Test_WhenSystemdNotifyIsCalled_ShouldSendState(t *testing.T) {
+	assert := assert2.New(t)
+	conn := listenNotifySocket(t)
+
+	assert.NoError(lifecycle.SystemdNotify("READY=1"))
+
+	state, ok := readNotification(conn, time.Second)
+	assert.True(ok)
+	assert.Equal("READY=1", state)
+}
+
+func Test_WhenChecksAreLive_ShouldFeedSystemdWatchdog(t *testing.T) {
+	assert := assert2.New(t)
+	conn := listenNotifySocket(t)
+	t.Setenv("WATCHDOG_USEC", "40000")
+
+	rdy := lifecycle.NewReadyCheck()
+	pulse := rdy.RegisterPulseComponent("worker", 100*time.Millisecond)
+	pulse.RecordPulse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- rdy.RunSystemdWatchdog(ctx)
+	}()
+
+	state, ok := readNotification(conn, time.Second)
+	assert.True(ok, "watchdog should be fed while the checks are live")
+	assert.Equal("WATCHDOG=1", state)
+
+	time.Sleep(150 * time.Millisecond)
+	for {
+		if _, ok := readNotification(conn, 10*time.Millisecond); !ok {
+			break
+		}
+	}
+
+	_, ok = readNotification(conn, 100*time.Millisecond)
+	assert.False(ok, "watchdog should not be fed once a check fails")
+
+	cancel()
+	assert.NoError(<-done)
+}
+
+func Test_WhenDraining_ShouldKeepFeedingSystemdWatchdog(t *testing.T) {
+	assert := assert2.New(t)
+	conn := listenNotifySocket(t)
+	t.Setenv("WATCHDOG_USEC", "40000")
+
+	clock := lifecycletest.NewClock(time.Now())
+	rdy := lifecycle.NewReadyCheckWithOptions(lifecycle.ReadyCheckOptions{Clock: clock})
+	rdy.RegisterPulseComponent("worker", time.Minute)
+	rdy.SetDraining(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- rdy.RunSystemdWatchdog(ctx)
+	}()
+
+	state, ok := readNotification(conn, time.Second)
+	assert.True(ok, "watchdog should be fed while draining")
+	assert.Equal("WATCHDOG=1", state)
+
+	<-clock.TimersCreated(1)
+	_, ok = readNotification(conn, 20*time.Millisecond)
+	assert.False(ok, "watchdog should be fed at the interval measured by the clock")
+
+	clock.Advance(20 * time.Millisecond)
+
+	state, ok = readNotification(conn, time.Second)
+	assert.True(ok)
+	assert.Equal("WATCHDOG=1", state)
+
+	cancel()
+	assert.NoError(<-done)
+}
+
+func Test_WhenSystemdWatchdogIsDisabled_ShouldReturnImmediately(t *testing.T) {
+	assert := assert2.New(t)
+	t.Setenv("WATCHDOG_USEC", "")
+
+	assert.NoError(lifecycle.NewReadyCheck().RunSystemdWatchdog(context.Background()))
+}
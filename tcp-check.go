@@ -0,0 +1,26 @@
+package lifecycle
+
+import (
+	"net"
+	"time"
+)
+
+// TCPCheck creates a [PollComponentCheck] dialing the given address in the background, each dial within the given timeout. It is
+// ready when a TCP connection can be established with the address, the connection being closed as soon as it is established.
+// The dials start once the check is registered and the ReadyCheck is started.
+func TCPCheck(name string, addr string, timeout time.Duration) *PollComponentCheck {
+	return TCPCheckWithOptions(name, addr, timeout, PollOptions{})
+}
+
+// TCPCheckWithOptions creates a [PollComponentCheck] dialing the given address in the background, polled with the given
+// options. While the component is not ready, the error of the last failed dial is reported in its [CheckResult].
+func TCPCheckWithOptions(name string, addr string, timeout time.Duration, options PollOptions) *PollComponentCheck {
+	return newPollComponentCheck(name, func() (bool, error) {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return false, err
+		}
+
+		return true, conn.Close()
+	}, options, SystemClock)
+}
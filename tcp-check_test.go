@@ -0,0 +1,88 @@
+package lifecycle_test
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func listenTCP(t *testing.T, accepted *atomic.Int32) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted.Add(1)
+			_ = conn.Close()
+		}
+	}()
+
+	return listener
+}
+
+func Test_WhenAddressIsReachable_ShouldBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	listener := listenTCP(t, &atomic.Int32{})
+	defer listener.Close()
+
+	check := lifecycle.TCPCheckWithOptions("postgres", listener.Addr().String(), time.Second, lifecycle.PollOptions{
+		Interval: 10 * time.Millisecond,
+	})
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("postgres", check)
+
+	readycheck.StartPolling()
+	defer readycheck.StopPolling()
+
+	assert.Equal("postgres", check.Name())
+	assert.Eventually(readycheck.Ready, time.Second, 10*time.Millisecond)
+}
+
+func Test_WhenAddressIsUnreachable_ShouldNotBeReady(t *testing.T) {
+	assert := assert2.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("postgres", lifecycle.TCPCheckWithOptions("postgres", addr, time.Second, lifecycle.PollOptions{
+		ImmediateCheck: true,
+	}))
+
+	assert.False(readycheck.Ready())
+	assert.Error(readycheck.ExplainDetailed()["postgres"].Err)
+}
+
+func Test_WhenTCPCheckIsChecked_ShouldNotDialAgain(t *testing.T) {
+	assert := assert2.New(t)
+
+	accepted := &atomic.Int32{}
+	listener := listenTCP(t, accepted)
+	defer listener.Close()
+
+	readycheck := lifecycle.NewReadyCheck()
+	readycheck.RegisterComponent("postgres", lifecycle.TCPCheckWithOptions("postgres", listener.Addr().String(), time.Second, lifecycle.PollOptions{
+		ImmediateCheck: true,
+		Interval:       time.Hour,
+	}))
+
+	assert.True(readycheck.Ready())
+	assert.True(readycheck.Ready())
+	assert.Eventually(func() bool { return accepted.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Never(func() bool { return accepted.Load() > 1 }, 50*time.Millisecond, 10*time.Millisecond)
+}
@@ -0,0 +1,24 @@
+module github.com/gretro/go-lifecycle/tracing
+
+go 1.20
+
+require (
+	github.com/gretro/go-lifecycle v0.0.0-20261016112221-8ef3bfae90f5
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/sdk v1.19.0
+	go.opentelemetry.io/otel/trace v1.19.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.19.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// The core module is built from this repository during development. Consumers resolve the version required above.
+replace github.com/gretro/go-lifecycle => ../
@@ -0,0 +1,129 @@
+// Package tracing instruments the shutdown sequence of a [lifecycle.GracefulShutdown] with OpenTelemetry spans.
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerOptions are options used in conjunction with the [Tracer] type
+type TracerOptions struct {
+	// TracerProvider is the provider used to create the spans
+	//
+	// Default: the global TracerProvider
+	TracerProvider trace.TracerProvider
+	// Context is the parent context of the shutdown span
+	//
+	// Default: context.Background()
+	Context context.Context
+}
+
+const (
+	// InstrumentationName is the name of the tracer used to create the spans
+	InstrumentationName = "github.com/gretro/go-lifecycle/tracing"
+
+	ShutdownSpanName  = "lifecycle.shutdown"
+	ComponentSpanName = "lifecycle.shutdown.component"
+
+	ComponentKey      = attribute.Key("lifecycle.component")
+	ShutdownReasonKey = attribute.Key("lifecycle.shutdown.reason")
+)
+
+// Tracer is a [lifecycle.Listener] which creates a root span covering the whole shutdown, as well as a child span for each
+// component's drain, recording its duration and error.
+type Tracer struct {
+	lifecycle.NoopListener
+
+	tracer    trace.Tracer
+	parentCtx context.Context
+
+	mutex        *sync.Mutex
+	shutdownCtx  context.Context
+	shutdownSpan trace.Span
+}
+
+// NewTracer creates a new [Tracer] and registers it as a listener of the given [lifecycle.GracefulShutdown]
+func NewTracer(gs *lifecycle.GracefulShutdown, options TracerOptions) *Tracer {
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
+
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+
+	tracer := &Tracer{
+		tracer:    options.TracerProvider.Tracer(InstrumentationName),
+		parentCtx: options.Context,
+		mutex:     &sync.Mutex{},
+	}
+
+	gs.AddListener(tracer)
+
+	return tracer
+}
+
+// ShutdownRequested implements [lifecycle.Listener] by starting the shutdown span
+func (tracer *Tracer) ShutdownRequested(reason error) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	attributes := make([]attribute.KeyValue, 0, 1)
+	if reason != nil {
+		attributes = append(attributes, ShutdownReasonKey.String(reason.Error()))
+	}
+
+	tracer.shutdownCtx, tracer.shutdownSpan = tracer.tracer.Start(tracer.parentCtx, ShutdownSpanName, trace.WithAttributes(attributes...))
+}
+
+// ComponentShutdownCompleted implements [lifecycle.Listener] by recording a span covering the component's drain
+func (tracer *Tracer) ComponentShutdownCompleted(name string, err error, duration time.Duration) {
+	tracer.mutex.Lock()
+	ctx := tracer.shutdownCtx
+	tracer.mutex.Unlock()
+
+	if ctx == nil {
+		ctx = tracer.parentCtx
+	}
+
+	end := time.Now()
+
+	_, span := tracer.tracer.Start(ctx, ComponentSpanName,
+		trace.WithTimestamp(end.Add(-duration)),
+		trace.WithAttributes(ComponentKey.String(name)),
+	)
+	endSpan(span, err, end)
+}
+
+// ShutdownFinished implements [lifecycle.Listener] by ending the shutdown span
+func (tracer *Tracer) ShutdownFinished(err error) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	if tracer.shutdownSpan == nil {
+		return
+	}
+
+	endSpan(tracer.shutdownSpan, err, time.Now())
+
+	tracer.shutdownCtx = nil
+	tracer.shutdownSpan = nil
+}
+
+func endSpan(span trace.Span, err error, end time.Time) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+
+	span.End(trace.WithTimestamp(end))
+}
@@ -0,0 +1,67 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	"github.com/gretro/go-lifecycle/tracing"
+	assert2 "github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_WhenShuttingDown_ShouldRecordSpans(t *testing.T) {
+	assert := assert2.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	tracing.NewTracer(gs, tracing.TracerOptions{TracerProvider: provider})
+
+	_ = gs.RegisterComponentWithFn("http-server", func() error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	_ = gs.RegisterComponentWithFn("db-pool", func() error {
+		return errors.New("test error")
+	})
+
+	_ = gs.Shutdown()
+
+	spans := recorder.Ended()
+	if !assert.Len(spans, 3) {
+		return
+	}
+
+	root := spans[2]
+	assert.Equal(tracing.ShutdownSpanName, root.Name())
+	assert.Equal(codes.Error, root.Status().Code)
+
+	components := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range spans[:2] {
+		assert.Equal(tracing.ComponentSpanName, span.Name())
+		assert.Equal(root.SpanContext().SpanID(), span.Parent().SpanID(), "component span should be a child of the shutdown span")
+
+		for _, attr := range span.Attributes() {
+			if attr.Key == tracing.ComponentKey {
+				components[attr.Value.AsString()] = span
+			}
+		}
+	}
+
+	httpSpan := components["http-server"]
+	if assert.NotNil(httpSpan) {
+		assert.Equal(codes.Ok, httpSpan.Status().Code)
+		assert.GreaterOrEqual(httpSpan.EndTime().Sub(httpSpan.StartTime()), 50*time.Millisecond)
+	}
+
+	dbSpan := components["db-pool"]
+	if assert.NotNil(dbSpan) {
+		assert.Equal(codes.Error, dbSpan.Status().Code)
+	}
+}
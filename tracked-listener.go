@@ -0,0 +1,142 @@
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// TrackedListenerOptions are options used in conjunction with the [TrackedListener] type
+type TrackedListenerOptions struct {
+	// OnClose is invoked with each open connection once the listener stops accepting, so that the protocol can notify the peer
+	// that the server is going away, such as an SMTP 421 reply. It is invoked concurrently for every connection.
+	//
+	// Default: nil
+	OnClose func(conn net.Conn)
+}
+
+// TrackedListener is a [net.Listener] tracking the connections it accepted, so that the shutdown can wait for them to finish.
+// It is a building block for TCP servers which do not provide a graceful shutdown of their own.
+type TrackedListener struct {
+	net.Listener
+	options TrackedListenerOptions
+
+	mutex       *sync.Mutex
+	connections map[*trackedConn]struct{}
+	idle        chan struct{}
+}
+
+// NewTrackedListener wraps the listener into a [*TrackedListener]
+func NewTrackedListener(listener net.Listener, options TrackedListenerOptions) *TrackedListener {
+	return &TrackedListener{
+		Listener:    listener,
+		options:     options,
+		mutex:       &sync.Mutex{},
+		connections: make(map[*trackedConn]struct{}),
+	}
+}
+
+// Accept waits for and returns the next connection, which is tracked until it is closed
+func (l *TrackedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := &trackedConn{Conn: conn, listener: l, closeOnce: &sync.Once{}}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.connections[tracked] = struct{}{}
+	if len(l.connections) == 1 {
+		l.idle = make(chan struct{})
+	}
+
+	return tracked, nil
+}
+
+// Connections returns the number of open connections
+func (l *TrackedListener) Connections() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.connections)
+}
+
+// Shutdown stops accepting new connections, notifies the open connections through [TrackedListenerOptions.OnClose], then
+// blocks until they are closed or the context is done. The connections still open once the context is done are closed.
+func (l *TrackedListener) Shutdown(ctx context.Context) error {
+	_ = l.Listener.Close()
+
+	l.mutex.Lock()
+	connections := l.snapshot()
+	idle := l.idle
+	l.mutex.Unlock()
+
+	if len(connections) == 0 {
+		return nil
+	}
+
+	if l.options.OnClose != nil {
+		for _, conn := range connections {
+			go l.options.OnClose(conn)
+		}
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		connections = l.snapshot()
+		l.mutex.Unlock()
+
+		for _, conn := range connections {
+			_ = conn.Close()
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (l *TrackedListener) snapshot() []net.Conn {
+	connections := make([]net.Conn, 0, len(l.connections))
+	for conn := range l.connections {
+		connections = append(connections, conn)
+	}
+
+	return connections
+}
+
+func (l *TrackedListener) release(conn *trackedConn) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.connections, conn)
+	if len(l.connections) == 0 {
+		close(l.idle)
+	}
+}
+
+// trackedConn removes itself from its listener once closed
+type trackedConn struct {
+	net.Conn
+	listener  *TrackedListener
+	closeOnce *sync.Once
+}
+
+func (conn *trackedConn) Close() error {
+	err := conn.Conn.Close()
+	conn.closeOnce.Do(func() {
+		conn.listener.release(conn)
+	})
+
+	return err
+}
+
+// RegisterTrackedListener registers a [*TrackedListener] as a component. When signaled, the listener stops accepting and waits
+// for its connections to finish, bounded by the remaining shutdown time. See [TrackedListener.Shutdown].
+func (gs *GracefulShutdown) RegisterTrackedListener(name string, listener *TrackedListener, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, listener.Shutdown, opts)
+}
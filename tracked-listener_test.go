@@ -0,0 +1,121 @@
+package lifecycle_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+// serveLines echoes the lines received on each connection until the peer sends "quit"
+func serveLines(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				if scanner.Text() == "quit" {
+					return
+				}
+				_, _ = conn.Write([]byte(scanner.Text() + "\n"))
+			}
+		}()
+	}
+}
+
+func newTrackedListener(t *testing.T, options lifecycle.TrackedListenerOptions) *lifecycle.TrackedListener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracked := lifecycle.NewTrackedListener(listener, options)
+	go serveLines(tracked)
+
+	return tracked
+}
+
+func Test_WhenTrackedListenerIsShutdown_ShouldWaitForConnections(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	notified := make(chan struct{}, 1)
+	listener := newTrackedListener(t, lifecycle.TrackedListenerOptions{
+		OnClose: func(conn net.Conn) {
+			_, _ = conn.Write([]byte("421 shutting down\n"))
+			notified <- struct{}{}
+		},
+	})
+	assert.NoError(gs.RegisterTrackedListener("smtp", listener))
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if !assert.NoError(err) {
+		return
+	}
+	defer conn.Close()
+
+	assert.Eventually(func() bool {
+		return listener.Connections() == 1
+	}, time.Second, time.Millisecond)
+
+	done := make(chan error)
+	go func() {
+		done <- gs.Shutdown()
+	}()
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	assert.NoError(err)
+	assert.Equal("421 shutting down\n", line)
+	<-notified
+
+	_, err = net.Dial("tcp", listener.Addr().String())
+	assert.Error(err, "listener should no longer accept connections")
+
+	select {
+	case <-done:
+		assert.Fail("shutdown should wait for the open connection")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	_, _ = conn.Write([]byte("quit\n"))
+	assert.NoError(<-done)
+	assert.Equal(0, listener.Connections())
+}
+
+func Test_WhenTrackedListenerTimesOut_ShouldCloseConnections(t *testing.T) {
+	assert := assert2.New(t)
+	listener := newTrackedListener(t, lifecycle.TrackedListenerOptions{})
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if !assert.NoError(err) {
+		return
+	}
+	defer conn.Close()
+
+	assert.Eventually(func() bool {
+		return listener.Connections() == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = listener.Shutdown(ctx)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(err, "connection should be closed by the server")
+	assert.Equal(0, listener.Connections())
+}
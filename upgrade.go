@@ -0,0 +1,230 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	DefaultUpgradeReadyTimeout = 30 * time.Second
+
+	ErrUpgradeNotSupported = errors.New("upgrades are not supported on this platform")
+	ErrUpgradeInProgress   = errors.New("upgrade is already in progress")
+	ErrUpgradeFailed       = errors.New("upgraded process did not become ready")
+	ErrUpgraded            = errors.New("process was replaced by an upgraded process")
+)
+
+const (
+	// upgradeListenersEnv lists the keys of the inherited listeners, in the order of their file descriptors
+	upgradeListenersEnv = "LIFECYCLE_UPGRADE_LISTENERS"
+	// upgradeReadyEnv is the file descriptor through which the upgraded process reports it is ready
+	upgradeReadyEnv = "LIFECYCLE_UPGRADE_READY_FD"
+	// firstInheritedFd is the file descriptor of the first file passed to a child process
+	firstInheritedFd = 3
+)
+
+// UpgraderOptions are options used in conjunction with the [Upgrader] type
+type UpgraderOptions struct {
+	// Signal triggers the upgrade while WaitForShutdown is running. The upgrade may also be triggered by calling Upgrade.
+	//
+	// Default: SIGUSR2 on the platforms supporting upgrades, none otherwise
+	Signal os.Signal
+	// ReadyTimeout is the time given to the upgraded process to report it is ready. Once it elapses, the upgraded process is
+	// killed and the current process keeps running.
+	//
+	// Default: 30s
+	ReadyTimeout time.Duration
+	// Path is the path of the binary started by the upgrade
+	//
+	// Default: the path of the current executable
+	Path string
+	// Args are the arguments given to the upgraded process, excluding the program name
+	//
+	// Default: the arguments of the current process
+	Args []string
+}
+
+// Upgrader replaces the process by a new one without downtime. The upgraded process inherits the listeners created through
+// Listen, waits to be ready, then the current process is drained through its [GracefulShutdown].
+type Upgrader struct {
+	gs      *GracefulShutdown
+	options UpgraderOptions
+
+	mutex     *sync.Mutex
+	listeners map[string]net.Listener
+	inherited map[string]*os.File
+	readyFile *os.File
+	upgrading bool
+}
+
+// NewUpgrader creates a new instance of [*Upgrader]. When the process was started by an upgrade, the listeners it inherited are
+// made available through Listen. When a Signal is configured, it is handled by the GracefulShutdown to trigger the upgrade.
+func NewUpgrader(gs *GracefulShutdown, options UpgraderOptions) (*Upgrader, error) {
+	if options.Signal == nil {
+		options.Signal = defaultUpgradeSignal
+	}
+
+	if options.ReadyTimeout <= 0 {
+		options.ReadyTimeout = DefaultUpgradeReadyTimeout
+	}
+
+	if options.Path == "" {
+		path, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		options.Path = path
+	}
+
+	if options.Args == nil {
+		options.Args = os.Args[1:]
+	}
+
+	u := &Upgrader{
+		gs:        gs,
+		options:   options,
+		mutex:     &sync.Mutex{},
+		listeners: make(map[string]net.Listener),
+		inherited: make(map[string]*os.File),
+	}
+
+	err := u.inherit()
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Signal != nil {
+		gs.HandleSignal(options.Signal, u.Upgrade)
+	}
+
+	return u, nil
+}
+
+// inherit collects the files passed by the parent process, if any
+func (u *Upgrader) inherit() error {
+	if keys := os.Getenv(upgradeListenersEnv); keys != "" {
+		for i, key := range strings.Split(keys, ";") {
+			u.inherited[key] = os.NewFile(uintptr(firstInheritedFd+i), key)
+		}
+	}
+
+	if fd := os.Getenv(upgradeReadyEnv); fd != "" {
+		readyFd, err := strconv.Atoi(fd)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", upgradeReadyEnv, err)
+		}
+
+		u.readyFile = os.NewFile(uintptr(readyFd), "upgrade-ready")
+	}
+
+	return nil
+}
+
+// HasParent returns true when the process was started by an upgrade
+func (u *Upgrader) HasParent() bool {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.readyFile != nil
+}
+
+// Listen returns the listener inherited from the parent process for the network and address, or creates a new one. The address
+// must be the same in both processes, since it identifies the listener. Listeners returned by Listen are passed to the upgraded
+// process.
+func (u *Upgrader) Listen(network string, addr string) (net.Listener, error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	key := network + ":" + addr
+	if listener, ok := u.listeners[key]; ok {
+		return listener, nil
+	}
+
+	var listener net.Listener
+	var err error
+
+	if file, ok := u.inherited[key]; ok {
+		listener, err = net.FileListener(file)
+		_ = file.Close()
+		delete(u.inherited, key)
+	} else {
+		listener, err = net.Listen(network, addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.listeners[key] = listener
+	return listener, nil
+}
+
+// Ready reports to the parent process that this process is ready, which makes the parent drain itself. The inherited listeners
+// which were not claimed through Listen are closed. Ready does nothing when the process was not started by an upgrade.
+func (u *Upgrader) Ready() error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	for key, file := range u.inherited {
+		_ = file.Close()
+		delete(u.inherited, key)
+	}
+
+	if u.readyFile == nil {
+		return nil
+	}
+
+	_, err := u.readyFile.Write([]byte{1})
+	closeErr := u.readyFile.Close()
+	u.readyFile = nil
+
+	return errors.Join(err, closeErr)
+}
+
+// Upgrade starts the upgraded process, passing it the listeners, and waits for it to be ready. Once it is, the shutdown of the
+// current process is triggered with [ErrUpgraded] as its reason. If the upgraded process exits or does not become ready in time,
+// an [ErrUpgradeFailed] error is returned and the current process keeps running. Only one upgrade may be in progress at a time.
+func (u *Upgrader) Upgrade(ctx context.Context) error {
+	u.mutex.Lock()
+	if u.upgrading {
+		u.mutex.Unlock()
+		return ErrUpgradeInProgress
+	}
+	u.upgrading = true
+
+	keys := make([]string, 0, len(u.listeners))
+	for key := range u.listeners {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	listeners := make([]net.Listener, len(keys))
+	for i, key := range keys {
+		listeners[i] = u.listeners[key]
+	}
+	u.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, u.options.ReadyTimeout)
+	defer cancel()
+
+	err := startUpgradedProcess(ctx, u.options, keys, listeners)
+	if err != nil {
+		u.mutex.Lock()
+		u.upgrading = false
+		u.mutex.Unlock()
+
+		return err
+	}
+
+	// The process remains flagged as upgrading, since it is being replaced
+	u.gs.requestShutdown(ErrUpgraded)
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package lifecycle
+
+import (
+	"context"
+	"net"
+	"os"
+)
+
+var defaultUpgradeSignal os.Signal
+
+func startUpgradedProcess(ctx context.Context, options UpgraderOptions, keys []string, listeners []net.Listener) error {
+	return ErrUpgradeNotSupported
+}
@@ -0,0 +1,102 @@
+//go:build unix
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var defaultUpgradeSignal os.Signal = syscall.SIGUSR2
+
+// fileListener is implemented by the listeners whose file descriptor can be passed to another process
+type fileListener interface {
+	File() (*os.File, error)
+}
+
+// startUpgradedProcess starts the upgraded process with the listeners, and waits until it reports it is ready
+func startUpgradedProcess(ctx context.Context, options UpgraderOptions, keys []string, listeners []net.Listener) error {
+	files := make([]*os.File, 0, len(listeners)+1)
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+
+	for i, listener := range listeners {
+		withFile, ok := listener.(fileListener)
+		if !ok {
+			return fmt.Errorf("listener %s cannot be passed to another process", keys[i])
+		}
+
+		file, err := withFile.File()
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyReader.Close()
+	files = append(files, readyWriter)
+
+	cmd := exec.Command(options.Path, options.Args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(os.Environ(),
+		upgradeListenersEnv+"="+strings.Join(keys, ";"),
+		upgradeReadyEnv+"="+strconv.Itoa(firstInheritedFd+len(files)-1),
+	)
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	// The parent's copy of the write end must be closed, so the read end sees the upgraded process exit
+	_ = readyWriter.Close()
+	files = files[:len(files)-1]
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	ready := make(chan bool, 1)
+	go func() {
+		buf := make([]byte, 1)
+		n, _ := readyReader.Read(buf)
+		ready <- n == 1
+	}()
+
+	select {
+	case isReady := <-ready:
+		if isReady {
+			return nil
+		}
+
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("%w: process exited", ErrUpgradeFailed)
+	case err := <-exited:
+		// The process may have reported it is ready right before exiting
+		if <-ready {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %v", ErrUpgradeFailed, err)
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("%w: %w", ErrUpgradeFailed, ctx.Err())
+	}
+}
@@ -0,0 +1,120 @@
+//go:build unix
+
+package lifecycle_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+const upgradeHelperEnv = "LIFECYCLE_TEST_UPGRADE_HELPER"
+
+// Test_UpgradeHelperProcess is the upgraded process started by the upgrade tests. It serves a single connection on the inherited
+// listener.
+func Test_UpgradeHelperProcess(t *testing.T) {
+	if os.Getenv(upgradeHelperEnv) == "" {
+		return
+	}
+
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	upgrader, err := lifecycle.NewUpgrader(gs, lifecycle.UpgraderOptions{})
+	if err != nil || !upgrader.HasParent() {
+		os.Exit(2)
+	}
+
+	listener, err := upgrader.Listen("tcp", os.Getenv(upgradeHelperEnv))
+	if err != nil {
+		os.Exit(3)
+	}
+
+	if os.Getenv("LIFECYCLE_TEST_UPGRADE_FAIL") != "" {
+		os.Exit(4)
+	}
+
+	if upgrader.Ready() != nil {
+		os.Exit(5)
+	}
+
+	conn, err := listener.Accept()
+	if err != nil {
+		os.Exit(6)
+	}
+	_, _ = conn.Write([]byte("upgraded"))
+	_ = conn.Close()
+
+	os.Exit(0)
+}
+
+func newTestUpgrader(t *testing.T, gs *lifecycle.GracefulShutdown) *lifecycle.Upgrader {
+	upgrader, err := lifecycle.NewUpgrader(gs, lifecycle.UpgraderOptions{
+		Path:         os.Args[0],
+		Args:         []string{"-test.run=^Test_UpgradeHelperProcess$"},
+		ReadyTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return upgrader
+}
+
+func Test_WhenUpgradedProcessIsReady_ShouldHandOverListenerAndShutdown(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	upgrader := newTestUpgrader(t, gs)
+	assert.False(upgrader.HasParent())
+
+	listener, err := upgrader.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(err) {
+		return
+	}
+	addr := listener.Addr().String()
+	t.Setenv(upgradeHelperEnv, "127.0.0.1:0")
+
+	assert.NoError(upgrader.Upgrade(context.Background()))
+
+	select {
+	case <-gs.AppContext().Done():
+	case <-time.After(time.Second):
+		assert.Fail("current process should be shutdown once the upgraded process is ready")
+		return
+	}
+	assert.ErrorIs(gs.ShutdownReason(), lifecycle.ErrUpgraded)
+
+	// The current process stops accepting, so the upgraded process serves the connection
+	assert.NoError(listener.Close())
+
+	conn, err := net.Dial("tcp", addr)
+	if !assert.NoError(err) {
+		return
+	}
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	response, err := io.ReadAll(conn)
+	assert.NoError(err)
+	assert.Equal("upgraded", string(response))
+}
+
+func Test_WhenUpgradedProcessFails_ShouldKeepRunning(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+	upgrader := newTestUpgrader(t, gs)
+
+	_, err := upgrader.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	t.Setenv(upgradeHelperEnv, "127.0.0.1:0")
+	t.Setenv("LIFECYCLE_TEST_UPGRADE_FAIL", "1")
+
+	err = upgrader.Upgrade(context.Background())
+
+	assert.ErrorIs(err, lifecycle.ErrUpgradeFailed)
+	assert.NoError(gs.AppContext().Err(), "current process should keep running")
+}
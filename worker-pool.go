@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// WorkerPoolOptions are options used in conjunction with the [WorkerPool] type
+type WorkerPoolOptions struct {
+	// Workers is the number of goroutines processing the jobs
+	//
+	// Default: the number of CPUs
+	Workers int
+	// QueueSize is the number of jobs which can be queued while all the workers are busy
+	//
+	// Default: 0
+	QueueSize int
+}
+
+// Job is a unit of work processed by a [WorkerPool]. The context is done when the pool fails to drain in time.
+type Job func(ctx context.Context)
+
+// WorkerPool runs jobs on a fixed number of worker goroutines. When drained, the pool stops accepting jobs and waits for the jobs
+// in progress to complete. Queued jobs which were not started yet are abandoned.
+type WorkerPool struct {
+	mutex *sync.RWMutex
+
+	jobs      chan Job
+	intake    chan struct{}
+	intakeEnd *sync.Once
+	stopped   bool
+
+	jobCtx    context.Context
+	cancelJob context.CancelFunc
+	workers   *sync.WaitGroup
+	active    *atomic.Int64
+	abandoned *atomic.Int64
+}
+
+var ErrWorkerPoolStopped = errors.New("worker pool no longer accepts jobs")
+
+// NewWorkerPoolWithOptions creates a new instance of [*WorkerPool] with the given options and starts its workers
+func NewWorkerPoolWithOptions(options WorkerPoolOptions) *WorkerPool {
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+
+	jobCtx, cancelJob := context.WithCancel(context.Background())
+
+	pool := &WorkerPool{
+		mutex: &sync.RWMutex{},
+
+		jobs:      make(chan Job, options.QueueSize),
+		intake:    make(chan struct{}),
+		intakeEnd: &sync.Once{},
+
+		jobCtx:    jobCtx,
+		cancelJob: cancelJob,
+		workers:   &sync.WaitGroup{},
+		active:    &atomic.Int64{},
+		abandoned: &atomic.Int64{},
+	}
+
+	pool.workers.Add(options.Workers)
+	for i := 0; i < options.Workers; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+// NewWorkerPool creates a new instance of [*WorkerPool] and starts its workers. Default options will be used.
+func NewWorkerPool() *WorkerPool {
+	return NewWorkerPoolWithOptions(WorkerPoolOptions{})
+}
+
+func (pool *WorkerPool) work() {
+	defer pool.workers.Done()
+
+	for {
+		select {
+		case <-pool.intake:
+			return
+		case job := <-pool.jobs:
+			select {
+			case <-pool.intake:
+				pool.abandoned.Add(1)
+				return
+			default:
+			}
+
+			pool.active.Add(1)
+			job(pool.jobCtx)
+			pool.active.Add(-1)
+		}
+	}
+}
+
+// Submit queues a job, blocking until a worker or a queue slot is available. It returns [ErrWorkerPoolStopped] once the pool
+// is draining.
+func (pool *WorkerPool) Submit(ctx context.Context, job Job) error {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	if pool.stopped {
+		return ErrWorkerPoolStopped
+	}
+
+	select {
+	case pool.jobs <- job:
+		return nil
+	case <-pool.intake:
+		return ErrWorkerPoolStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Abandoned returns the number of jobs which were submitted but did not complete because the pool was drained
+func (pool *WorkerPool) Abandoned() int {
+	return int(pool.abandoned.Load())
+}
+
+// Drain stops accepting jobs, abandons the queued jobs and waits for the jobs in progress to complete. If the context is done
+// first, the context given to the jobs is cancelled, and the jobs still in progress are counted as abandoned.
+func (pool *WorkerPool) Drain(ctx context.Context) error {
+	pool.intakeEnd.Do(func() {
+		close(pool.intake)
+	})
+
+	// Waits for the pending submissions to give up before counting the queued jobs
+	pool.mutex.Lock()
+	pool.stopped = true
+	pool.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		pool.workers.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		pool.cancelJob()
+		pool.abandoned.Add(pool.active.Load())
+		err = ctx.Err()
+	}
+
+	pool.abandonQueuedJobs()
+
+	if err != nil {
+		return fmt.Errorf("%d jobs abandoned: %w", pool.Abandoned(), err)
+	}
+
+	pool.cancelJob()
+
+	return nil
+}
+
+func (pool *WorkerPool) abandonQueuedJobs() {
+	for {
+		select {
+		case <-pool.jobs:
+			pool.abandoned.Add(1)
+		default:
+			return
+		}
+	}
+}
+
+// RegisterWorkerPool registers a [*WorkerPool] as a component. When signaled, the pool is drained within the remaining shutdown
+// time. See [WorkerPool.Drain].
+func (gs *GracefulShutdown) RegisterWorkerPool(name string, pool *WorkerPool, opts ...ComponentOption) error {
+	return gs.registerComponentFn(name, DefaultPhase, pool.Drain, opts)
+}
@@ -0,0 +1,92 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gretro/go-lifecycle"
+	assert2 "github.com/stretchr/testify/assert"
+)
+
+func Test_WhenWorkerPoolIsRegistered_ShouldWaitForJobsInProgress(t *testing.T) {
+	assert := assert2.New(t)
+	gs := lifecycle.NewGracefulShutdown(context.Background())
+
+	pool := lifecycle.NewWorkerPoolWithOptions(lifecycle.WorkerPoolOptions{Workers: 2})
+	assert.NoError(gs.RegisterWorkerPool("workers", pool))
+
+	completed := atomic.Int32{}
+	for i := 0; i < 2; i++ {
+		err := pool.Submit(context.Background(), func(ctx context.Context) {
+			time.Sleep(100 * time.Millisecond)
+			completed.Add(1)
+		})
+		assert.NoError(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	assert.NoError(gs.Shutdown())
+	assert.Equal(int32(2), completed.Load(), "jobs in progress should complete")
+	assert.Equal(0, pool.Abandoned())
+
+	err := pool.Submit(context.Background(), func(ctx context.Context) {})
+	assert.ErrorIs(err, lifecycle.ErrWorkerPoolStopped)
+}
+
+func Test_WhenWorkerPoolIsDrained_ShouldAbandonQueuedJobs(t *testing.T) {
+	assert := assert2.New(t)
+
+	pool := lifecycle.NewWorkerPoolWithOptions(lifecycle.WorkerPoolOptions{Workers: 1, QueueSize: 3})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	assert.NoError(pool.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(pool.Submit(context.Background(), func(ctx context.Context) {
+			assert.Fail("queued job should not run once the pool is drained")
+		}))
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+
+	assert.NoError(pool.Drain(context.Background()))
+	assert.Equal(3, pool.Abandoned())
+}
+
+func Test_WhenWorkerPoolDrainTimesOut_ShouldCancelJobsInProgress(t *testing.T) {
+	assert := assert2.New(t)
+
+	pool := lifecycle.NewWorkerPoolWithOptions(lifecycle.WorkerPoolOptions{Workers: 1})
+
+	cancelled := make(chan struct{})
+	started := make(chan struct{})
+	assert.NoError(pool.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := pool.Drain(ctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Equal(1, pool.Abandoned())
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		assert.Fail("job context should be cancelled")
+	}
+}